@@ -11,3 +11,6 @@ require (
 )
 
 require github.com/bloeys/gglm v0.3.1 // indirect
+
+// The fork exposes the animations and the names of the material properties
+replace github.com/bloeys/assimp-go => ./third_party/assimp-go
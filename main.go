@@ -1,14 +1,17 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"gayEngine/renderer"
 
 	"github.com/go-gl/gl/v3.3-core/gl"
 	"github.com/go-gl/glfw/v3.3/glfw"
-	"github.com/go-gl/mathgl/mgl32"
 	glm "github.com/go-gl/mathgl/mgl32"
 )
 
@@ -16,6 +19,12 @@ const (
 	wWidth  = 1280
 	wHeight = 720
 	wTitle  = "GEngineG"
+
+	// Radius of the sphere around the origin that contains the scene, used to fit the shadow maps
+	sceneRadius = 5.
+
+	// Samples of the multisampled framebuffer of the scene
+	msaaSamples = 4
 )
 
 var (
@@ -78,6 +87,23 @@ var (
 	lastX      float64
 	lastY      float64
 	firstMouse bool = true
+
+	// The scene is drawn multisampled in HDR and resolved into a texture before it goes to the window, both follow its size
+	sceneFramebuffer   *renderer.Framebuffer
+	resolveFramebuffer *renderer.Framebuffer
+
+	// Effects applied to the resolved scene before it goes to the window
+	postProcess *renderer.PostProcessStack
+
+	// Models that can be picked with the mouse, and the box of the last picked mesh to focus the camera on it
+	scene        *renderer.Scene
+	selection    renderer.AABB
+	hasSelection bool
+	// What was picked, for the window title
+	selectionInfo = "nothing selected"
+
+	// The title is updated once per second, or at once when the input changes what it shows
+	titleChanged bool
 )
 
 func init() {
@@ -93,6 +119,24 @@ func init() {
 }
 
 func main() {
+	// In headless mode the window is hidden and a fixed number of frames is rendered from the starting camera and saved as PNG,
+	// so it can run in CI with a software renderer like Mesa's llvmpipe (LIBGL_ALWAYS_SOFTWARE=1 under xvfb-run)
+	headless := flag.Bool("headless", false, "render without showing the window and save the frames as PNG")
+	frames := flag.Int("frames", 1, "number of frames to render in headless mode")
+	outDir := flag.String("out", "captures", "directory of the frames saved in headless mode")
+	golden := flag.String("golden", "", "golden PNG to compare the last headless frame with")
+	updateGolden := flag.Bool("update-golden", false, "overwrite the golden PNG with the last headless frame")
+	tolerance := flag.Int("tolerance", 2, "difference allowed in each channel when comparing with the golden PNG, from 0 to 255")
+	reversedZ := flag.Bool("reversed-z", false, "reverse the depth of the camera for more precision in the distance, it needs glClipControl")
+	maxMismatch := flag.Float64("max-mismatch", 0, "fraction of the pixels that can differ from the golden PNG, from 0 to 1")
+	flag.Parse()
+	if *tolerance < 0 || *tolerance > 255 {
+		panic(fmt.Sprintf("The tolerance must be between 0 and 255, got %d", *tolerance))
+	}
+	if *maxMismatch < 0 || *maxMismatch > 1 {
+		panic(fmt.Sprintf("The maximum mismatch must be between 0 and 1, got %v", *maxMismatch))
+	}
+
 	// Initialize glfw and ensure if there is any error
 	if err := glfw.Init(); err != nil {
 		panic(err)
@@ -103,6 +147,9 @@ func main() {
 	glfw.WindowHint(glfw.ContextVersionMajor, 3)
 	glfw.WindowHint(glfw.ContextVersionMinor, 3)
 	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+	if *headless {
+		glfw.WindowHint(glfw.Visible, glfw.False)
+	}
 
 	// We create a window object
 	window, err := glfw.CreateWindow(wWidth, wHeight, "Hello Go", nil, nil)
@@ -117,9 +164,13 @@ func main() {
 	window.SetFramebufferSizeCallback(framebufferSizeCallback)
 	window.SetCursorPosCallback(mouseCallBack)
 	window.SetScrollCallback(scrollCallBack)
+	window.SetKeyCallback(keyCallback)
+	window.SetMouseButtonCallback(mouseButtonCallback)
 
 	// Tell GLFW to capture our mouse
-	window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	if !*headless {
+		window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	}
 
 	// Initialize glad
 	if err := gl.Init(); err != nil {
@@ -132,47 +183,254 @@ func main() {
 	glfw.SwapInterval(1)
 	gl.Enable(gl.DEPTH_TEST)
 
+	// The reversed depth needs the clip control and a float depth buffer in the framebuffers of the scene
+	if *reversedZ {
+		if renderer.ClipControlSupported() {
+			camera.ReversedZ = true
+		} else {
+			fmt.Printf("Warning: glClipControl is not supported, the depth is not reversed\n")
+		}
+	}
+
+	// Off-screen framebuffers of the scene
+	fbWidth, fbHeight := window.GetFramebufferSize()
+	camera.SetAspect(fbWidth, fbHeight)
+	sceneFramebuffer, err = renderer.NewFramebuffer(renderer.FramebufferConfig{
+		Width: int32(fbWidth), Height: int32(fbHeight),
+		Colors:     []renderer.ColorAttachment{renderer.ColorRGBA16F},
+		Samples:    msaaSamples,
+		Depth:      renderer.DepthRenderbuffer,
+		FloatDepth: camera.ReversedZ,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("Scene framebuffer creation failed %v", err))
+	}
+	defer sceneFramebuffer.Delete()
+	resolveFramebuffer, err = renderer.NewFramebuffer(renderer.FramebufferConfig{
+		Width: int32(fbWidth), Height: int32(fbHeight),
+		Colors:     []renderer.ColorAttachment{renderer.ColorRGBA16F},
+		Depth:      renderer.DepthTexture,
+		FloatDepth: camera.ReversedZ,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("Resolve framebuffer creation failed %v", err))
+	}
+	defer resolveFramebuffer.Delete()
+	postProcess, err = renderer.NewPostProcessStack("shaders", int32(fbWidth), int32(fbHeight))
+	if err != nil {
+		panic(fmt.Sprintf("Post processing creation failed %v", err))
+	}
+	defer postProcess.Delete()
+
+	// In headless mode the final image goes to a framebuffer of our own, the hidden window may not have one that can be read
+	var captureFramebuffer *renderer.Framebuffer
+	if *headless {
+		captureFramebuffer, err = renderer.NewFramebuffer(renderer.FramebufferConfig{
+			Width: int32(fbWidth), Height: int32(fbHeight),
+			Colors: []renderer.ColorAttachment{renderer.ColorRGBA8},
+		})
+		if err != nil {
+			panic(fmt.Sprintf("Capture framebuffer creation failed %v", err))
+		}
+		defer captureFramebuffer.Delete()
+	}
+
 	// Model
-	model0 := renderer.NewModel("resources/objects/backpack/backpack.obj")
+	// The backpack stores its normal map in map_Bump, which Assimp loads as a height map, and OBJ can't reference its AO map
+	model0, err := renderer.NewModelWithOptions("resources/objects/backpack/backpack.obj", renderer.ModelOptions{HeightAsNormalMap: true, AOMap: "ao.jpg"})
+	if err != nil {
+		panic(fmt.Sprintf("Model loading failed %v", err))
+	}
+	// We set in the origin of coordinates, and scale it into 1 dimension
+	model0.Transform.SetPosition(glm.Vec3{0., 0., 0.})
+	model0.Transform.SetScale(glm.Vec3{1., 1., 1.})
+
+	scene = renderer.NewScene(model0)
+
+	// Smaller copies of the model around it, drawn with one call per mesh
+	model0.Instances = make([]glm.Mat4, len(cubePos))
+	for i, pos := range cubePos {
+		model0.Instances[i] = glm.Translate3D(pos[0]*4, pos[1]*4, pos[2]*4).Mul4(glm.Scale3D(0.5, 0.5, 0.5))
+	}
 
 	// We create the shader program from our shader struct that we have created externally
-	shader0, err := renderer.NewShader("shaders/vShader.glsl", "shaders/fShader.glsl")
+	// Rigged models need the skinning vertex shader and an animator to move their bones, which plays the first clip of the file
+	vertexShaderPath := "shaders/lightingVShader.glsl"
+	if model0.Skeleton != nil {
+		vertexShaderPath = "shaders/skinnedVShader.glsl"
+		animator := renderer.NewAnimator(model0)
+		if len(model0.Clips) > 0 {
+			animator.Play(model0.Clips[0], true)
+		}
+	}
+	shader0, err := renderer.NewShader(vertexShaderPath, "shaders/pbrFShader.glsl")
 	if err != nil {
 		panic(fmt.Sprintf("Shader creation failed%v", err))
 	}
 	defer shader0.Delete()
 
+	// Shader for the depth pass of the shadow maps
+	depthShader, err := renderer.NewShader("shaders/depthVShader.glsl", "shaders/depthFShader.glsl")
+	if err != nil {
+		panic(fmt.Sprintf("Depth shader creation failed%v", err))
+	}
+	defer depthShader.Delete()
+
+	// Shader for the depth pass of the point lights, it writes the distance to the light
+	pointDepthShader, err := renderer.NewShader("shaders/pointDepthVShader.glsl", "shaders/pointDepthFShader.glsl")
+	if err != nil {
+		panic(fmt.Sprintf("Point depth shader creation failed%v", err))
+	}
+	defer pointDepthShader.Delete()
+
+	// Environment for the image based lighting, the scene still works with the constant ambient light without it
+	environment, err := renderer.NewEnvironment("textures/environment.hdr", renderer.EnvironmentOptions{CacheDir: "cache"})
+	if err != nil {
+		fmt.Printf("Warning: image based lighting disabled: %v\n", err)
+	} else {
+		defer environment.Delete()
+	}
+
+	// Skybox of the background, from the six images of the folder or else the environment, without both we keep the clear color
+	skyboxShader, err := renderer.NewShader("shaders/skyboxVShader.glsl", "shaders/skyboxFShader.glsl")
+	if err != nil {
+		panic(fmt.Sprintf("Skybox shader creation failed%v", err))
+	}
+	defer skyboxShader.Delete()
+	skybox, err := renderer.NewSkybox([6]string{
+		"textures/skybox/right.jpg", "textures/skybox/left.jpg",
+		"textures/skybox/top.jpg", "textures/skybox/bottom.jpg",
+		"textures/skybox/front.jpg", "textures/skybox/back.jpg",
+	})
+	if err != nil {
+		if environment != nil {
+			skybox = renderer.NewSkyboxFromCubemap(environment.Cubemap)
+		} else {
+			fmt.Printf("Warning: no skybox: %v\n", err)
+		}
+	}
+	if skybox != nil {
+		defer skybox.Delete()
+	}
+
+	// Lights of the scene, a sun and a warm point light next to the model, both casting shadows
+	sun := renderer.NewDirectionalLight(glm.Vec3{-0.2, -1., -0.3}, glm.Vec3{0.8, 0.8, 0.8})
+	if err := sun.EnableShadows(renderer.ShadowMapSize); err != nil {
+		panic(fmt.Sprintf("Shadow map creation failed %v", err))
+	}
+	lamp := renderer.NewPointLight(glm.Vec3{1.5, 1., 2.}, glm.Vec3{1., 0.8, 0.6})
+	if err := lamp.EnableShadows(renderer.ShadowMapSize/2, renderer.PointShadowFarPlane); err != nil {
+		panic(fmt.Sprintf("Point shadow map creation failed %v", err))
+	}
+	lights := &renderer.LightSet{}
+	for _, light := range []renderer.Light{sun, lamp} {
+		if err := lights.Add(light); err != nil {
+			fmt.Printf("Warning: light not added: %v\n", err)
+		}
+	}
+
+	// The draws of the frame are sorted to change the state as little as possible, its counters go to the title once per second
+	queue := renderer.NewRenderQueue()
+	lastTitle := 0.
+
+	frame := 0
 	for !window.ShouldClose() {
 		currentFrame := glfw.GetTime()
+		if *headless {
+			// Fixed time step, so every run renders the same frames
+			currentFrame = float64(frame) / 60.
+		}
 		deltaTime = float32(currentFrame) - lastFrame
 		lastFrame = float32(currentFrame)
 
-		processInput(window)
+		if !*headless {
+			processInput(window)
+		}
+		camera.Update(deltaTime)
+		if model0.Animator != nil {
+			model0.Animator.Update(deltaTime)
+		}
+
+		// We reload the shader between frames if its source files have been modified
+		if _, err := shader0.ReloadIfChanged(); err != nil {
+			fmt.Printf("Shader reload failed, keeping the previous program: %v\n", err)
+		}
+
 		width, height := window.GetFramebufferSize()
 
+		// We render the shadow maps first, the scene fits in a sphere of radius sceneRadius around the origin
+		lights.RenderShadows(depthShader, []*renderer.Model{model0}, glm.Vec3{0, 0, 0}, sceneRadius)
+		lights.RenderPointShadows(pointDepthShader, []*renderer.Model{model0})
+
+		// The scene goes to its framebuffer instead of the window
+		sceneFramebuffer.Bind()
+		camera.ApplyDepthState()
 		gl.ClearColor(0.2, 0.3, 0.3, 1.)
 		gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
 
 		shader0.Use()
 
-		// We pass the projection matrix to the shader
-		projection := glm.Perspective(glm.DegToRad(camera.Zoom), float32(width)/float32(height), 0.1, 100.0)
-		shader0.SetMat4("projection", projection)
+		// We pass the projection matrix of the camera to the shader
+		shader0.SetMat4("projection", camera.ProjectionMatrix())
 
 		// Camera/view trasformation
 		view := camera.GetViewMatrix()
 		shader0.SetMat4("view", view)
 
-		// Render the loaded model
-		model := mgl32.Ident4()
-		// We set in the origin of coordinates, and scale it into 1 dimension
-		translation := mgl32.Translate3D(0.0, 0.0, 0.0)
-		model = model.Mul4(translation)
-		scale := mgl32.Scale3D(1.0, 1.0, 1.0)
-		model = model.Mul4(scale)
-		// We set the model matrix and draw the model
-		shader0.SetMat4("model", model)
-		model0.Draw(*shader0)
+		// We upload the lights and the camera position for the specular highlights
+		lights.Apply(shader0, camera)
+		environment.Apply(shader0)
+		skybox.Apply(shader0)
+
+		// The queue draws the meshes of the model with the matrix of their nodes, sorted by the state they need
+		// The meshes outside of the view of the camera are skipped
+		frustum := camera.Frustum()
+		queue.Frustum = &frustum
+		queue.SubmitModel(model0, shader0)
+		queue.DrawOpaque()
+		model0.DrawInstanced(*shader0, model0.InstancesInFrustum(frustum))
+
+		// The skybox goes after the opaque objects, only where nothing else was drawn, and before the transparent ones that show it
+		if skybox != nil {
+			skybox.Draw(skyboxShader, camera)
+		}
+		stats := queue.DrawTransparent(camera.Position)
+		if titleChanged || currentFrame-lastTitle >= 1 {
+			window.SetTitle(fmt.Sprintf("Hello Go - %d draws, %d culled, %d shader, %d material, %d VAO changes, %d texture binds, %d skipped | %s | %s",
+				stats.DrawCalls, stats.Culled, stats.ShaderChanges, stats.MaterialChanges, stats.VAOChanges, stats.TextureBinds, stats.SkippedChanges,
+				enabledPasses(), selectionInfo))
+			lastTitle = currentFrame
+			titleChanged = false
+		}
+
+		// The shadow maps of the next frame use the default depth
+		renderer.ResetDepthState()
+
+		// We resolve the samples and the post processing draws the result into the window
+		sceneFramebuffer.Resolve(resolveFramebuffer)
+		postProcess.RenderTo(resolveFramebuffer.ColorAttachments[0], captureFramebuffer, int32(width), int32(height))
+
+		if *headless {
+			img := renderer.CaptureFramebuffer(captureFramebuffer, 0, 0)
+			if err := renderer.SavePNG(filepath.Join(*outDir, fmt.Sprintf("frame_%03d.png", frame)), img); err != nil {
+				panic(fmt.Sprintf("Frame capture failed %v", err))
+			}
+			frame++
+			if frame >= *frames {
+				if *golden != "" {
+					err := renderer.CompareGolden(*golden, img, renderer.GoldenOptions{
+						Tolerance:        uint8(*tolerance),
+						MaxMismatchRatio: *maxMismatch,
+						Update:           *updateGolden,
+					})
+					if err != nil {
+						fmt.Println(err)
+						os.Exit(1)
+					}
+				}
+				break
+			}
+		}
 
 		window.SwapBuffers()
 		glfw.PollEvents()
@@ -207,10 +465,134 @@ func processInput(window *glfw.Window) {
 	if window.GetKey(glfw.KeyLeftShift) == glfw.Press {
 		camera.ProcessKeyBoard(5, deltaTime)
 	}
+	// Q and E roll the free look, and control sprints
+	if window.GetKey(glfw.KeyQ) == glfw.Press {
+		camera.ProcessKeyBoard(renderer.RollLeft, deltaTime)
+	}
+	if window.GetKey(glfw.KeyE) == glfw.Press {
+		camera.ProcessKeyBoard(renderer.RollRight, deltaTime)
+	}
+	camera.Sprint = window.GetKey(glfw.KeyLeftControl) == glfw.Press
 }
 
 func framebufferSizeCallback(window *glfw.Window, width int, height int) {
 	gl.Viewport(0, 0, int32(width), int32(height))
+	camera.SetAspect(width, height)
+	// The off-screen framebuffers follow the window, a minimized window keeps them as they are
+	if sceneFramebuffer == nil || width == 0 || height == 0 {
+		return
+	}
+	for _, framebuffer := range []*renderer.Framebuffer{sceneFramebuffer, resolveFramebuffer} {
+		if err := framebuffer.Resize(int32(width), int32(height)); err != nil {
+			fmt.Printf("Warning: framebuffer resize failed: %v\n", err)
+		}
+	}
+	if err := postProcess.Resize(int32(width), int32(height)); err != nil {
+		fmt.Printf("Warning: post processing resize failed: %v\n", err)
+	}
+}
+
+// Function for the keys that act once per press. Tab switches between the fly and the orbit camera, F focuses on the selection,
+// O switches between the perspective and the orthographic projection, V switches the free look, the number keys toggle the post processing passes in their order, and minus and equal change the exposure
+func keyCallback(window *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	if action != glfw.Press {
+		return
+	}
+	switch key {
+	case glfw.KeyTab:
+		// The orbit mode needs the cursor to drag, the fly mode captures it
+		if camera.Mode == renderer.FPSMode {
+			camera.SetMode(renderer.OrbitMode)
+			window.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+		} else {
+			camera.SetMode(renderer.FPSMode)
+			window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+		}
+		firstMouse = true
+		return
+	case glfw.KeyV:
+		if camera.Mode == renderer.FPSMode {
+			camera.SetFreeLook(!camera.FreeLook)
+		}
+		return
+	case glfw.KeyO:
+		if camera.Projection == renderer.Perspective {
+			camera.Projection = renderer.Orthographic
+		} else {
+			camera.Projection = renderer.Perspective
+		}
+		return
+	case glfw.KeyF:
+		if hasSelection {
+			camera.Focus(selection)
+		} else if scene != nil && len(scene.Models) > 0 {
+			camera.Focus(scene.Models[0].Bounds())
+		}
+		return
+	}
+	if postProcess == nil {
+		return
+	}
+	if key >= glfw.Key1 && key <= glfw.Key9 {
+		if i := int(key - glfw.Key1); i < len(postProcess.Passes) {
+			pass := postProcess.Passes[i]
+			pass.SetEnabled(!pass.Enabled())
+			titleChanged = true
+		}
+		return
+	}
+	if toneMapping, ok := renderer.FindPass[*renderer.ToneMappingPass](postProcess); ok {
+		switch key {
+		case glfw.KeyMinus:
+			toneMapping.Exposure /= 1.25
+		case glfw.KeyEqual:
+			toneMapping.Exposure *= 1.25
+		}
+	}
+}
+
+// Function that picks with the left button what is under the cursor. In the fly mode the cursor is captured,
+// so it picks what is in the middle of the screen where the camera looks
+func mouseButtonCallback(window *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+	if button != glfw.MouseButtonLeft || action != glfw.Press || scene == nil {
+		return
+	}
+	width, height := window.GetFramebufferSize()
+	x, y := float32(width)/2, float32(height)/2
+	if camera.Mode == renderer.OrbitMode {
+		// The cursor is in screen coordinates, which can be smaller than the pixels of the framebuffer
+		cursorX, cursorY := window.GetCursorPos()
+		windowWidth, windowHeight := window.GetSize()
+		x = float32(cursorX) * float32(width) / float32(windowWidth)
+		y = float32(cursorY) * float32(height) / float32(windowHeight)
+	}
+	ray := camera.ScreenPointToRay(x, y, float32(width), float32(height))
+	if hit, ok := scene.Raycast(ray); ok {
+		selection, hasSelection = hit.Bounds, true
+		selectionInfo = fmt.Sprintf("selected triangle %d of a mesh with %d vertices, %.2f away", hit.Triangle, len(hit.Mesh.Vertices), hit.Distance)
+	} else {
+		hasSelection = false
+		selectionInfo = "nothing selected"
+	}
+	titleChanged = true
+}
+
+// It lists the post processing passes that are enabled, by the name of their type
+func enabledPasses() string {
+	if postProcess == nil {
+		return "no post processing"
+	}
+	var names []string
+	for _, pass := range postProcess.Passes {
+		if pass.Enabled() {
+			name := strings.TrimPrefix(fmt.Sprintf("%T", pass), "*renderer.")
+			names = append(names, strings.TrimSuffix(name, "Pass"))
+		}
+	}
+	if len(names) == 0 {
+		return "no post processing"
+	}
+	return strings.Join(names, ", ")
 }
 
 // Function to process the mouse movement
@@ -230,6 +612,15 @@ func mouseCallBack(window *glfw.Window, xposIn, yposIn float64) {
 	lastX = xpos
 	lastY = ypos
 
+	// The orbit camera only moves while dragging, rotating with the left button and panning with the others
+	if camera.Mode == renderer.OrbitMode {
+		if window.GetMouseButton(glfw.MouseButtonLeft) == glfw.Press {
+			camera.ProcessMouseMovement(float32(xoffset), float32(yoffset), true)
+		} else if window.GetMouseButton(glfw.MouseButtonRight) == glfw.Press || window.GetMouseButton(glfw.MouseButtonMiddle) == glfw.Press {
+			camera.Pan(float32(xoffset), float32(yoffset))
+		}
+		return
+	}
 	camera.ProcessMouseMovement(float32(xoffset), float32(yoffset), true)
 }
 
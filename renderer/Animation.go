@@ -0,0 +1,173 @@
+package renderer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/bloeys/assimp-go/asig"
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Ticks per second used when the clip doesn't say it
+const DefaultTicksPerSecond = float32(25.)
+
+type VectorKey struct {
+	Time  float32
+	Value glm.Vec3
+}
+
+type QuatKey struct {
+	Time  float32
+	Value glm.Quat
+}
+
+// Keyframes of the node with the same name, the keys must be sorted by time
+type Channel struct {
+	Positions []VectorKey
+	Rotations []QuatKey
+	Scales    []VectorKey
+}
+
+// Animation of the nodes of a model, it follows the layout of the Assimp animations (aiAnimation).
+// The clips of a file are loaded with the model, NewAnimationClip builds one by hand
+type AnimationClip struct {
+	Name string
+	// Duration in ticks
+	Duration       float32
+	TicksPerSecond float32
+	// Channels by node name
+	Channels map[string]*Channel
+}
+
+func NewAnimationClip(name string, duration, ticksPerSecond float32) *AnimationClip {
+	if ticksPerSecond == 0 {
+		ticksPerSecond = DefaultTicksPerSecond
+	}
+	return &AnimationClip{
+		Name:           name,
+		Duration:       duration,
+		TicksPerSecond: ticksPerSecond,
+		Channels:       make(map[string]*Channel),
+	}
+}
+
+// It converts the Assimp animation, the keys of the channels keep their times in ticks
+func clipFromAssimp(animation *asig.Animation) *AnimationClip {
+	clip := NewAnimationClip(animation.Name, float32(animation.Duration), float32(animation.TicksPerSecond))
+	for _, nodeAnim := range animation.Channels {
+		channel := &Channel{}
+		for _, key := range nodeAnim.PositionKeys {
+			channel.Positions = append(channel.Positions, VectorKey{Time: float32(key.Time), Value: glm.Vec3(key.Value.Data)})
+		}
+		for _, key := range nodeAnim.RotationKeys {
+			// The binding stores the quaternions as x, y, z, w
+			q := key.Value.Data
+			channel.Rotations = append(channel.Rotations, QuatKey{Time: float32(key.Time), Value: glm.Quat{W: q[3], V: glm.Vec3{q[0], q[1], q[2]}}})
+		}
+		for _, key := range nodeAnim.ScalingKeys {
+			channel.Scales = append(channel.Scales, VectorKey{Time: float32(key.Time), Value: glm.Vec3(key.Value.Data)})
+		}
+		clip.Channels[nodeAnim.NodeName] = channel
+	}
+	return clip
+}
+
+// Position, rotation and scale of a node at some point of a clip
+type pose struct {
+	position glm.Vec3
+	rotation glm.Quat
+	scale    glm.Vec3
+}
+
+func (p pose) matrix() glm.Mat4 {
+	translation := glm.Translate3D(p.position[0], p.position[1], p.position[2])
+	scale := glm.Scale3D(p.scale[0], p.scale[1], p.scale[2])
+	return translation.Mul4(p.rotation.Mat4()).Mul4(scale)
+}
+
+// It splits a transformation without shear into its position, rotation and scale
+func poseFromMatrix(m glm.Mat4) pose {
+	scale := glm.Vec3{m.Col(0).Vec3().Len(), m.Col(1).Vec3().Len(), m.Col(2).Vec3().Len()}
+	rotation := glm.Mat4{}
+	for i := 0; i < 3; i++ {
+		if scale[i] != 0 {
+			rotation.SetCol(i, m.Col(i).Mul(1/scale[i]))
+		}
+	}
+	rotation.SetCol(3, glm.Vec4{0, 0, 0, 1})
+	return pose{
+		position: m.Col(3).Vec3(),
+		rotation: glm.Mat4ToQuat(rotation).Normalize(),
+		scale:    scale,
+	}
+}
+
+// It blends two poses, weight 0 is a and weight 1 is b
+func blendPoses(a, b pose, weight float32) pose {
+	return pose{
+		position: lerpVec3(a.position, b.position, weight),
+		rotation: glm.QuatSlerp(a.rotation, b.rotation, weight),
+		scale:    lerpVec3(a.scale, b.scale, weight),
+	}
+}
+
+// It samples the channel at the given time in ticks, interpolating between the keys around it
+func (c *Channel) sample(time float32) pose {
+	return pose{
+		position: sampleVectorKeys(c.Positions, time, glm.Vec3{0, 0, 0}),
+		rotation: sampleQuatKeys(c.Rotations, time),
+		scale:    sampleVectorKeys(c.Scales, time, glm.Vec3{1, 1, 1}),
+	}
+}
+
+// It returns the index of the key before time and how far time is between it and the next one
+func findKey(count int, keyTime func(i int) float32, time float32) (int, float32) {
+	next := sort.Search(count, func(i int) bool { return keyTime(i) > time })
+	if next == 0 {
+		return 0, 0
+	}
+	if next == count {
+		return count - 1, 0
+	}
+	previous := next - 1
+	factor := (time - keyTime(previous)) / (keyTime(next) - keyTime(previous))
+	return previous, factor
+}
+
+func sampleVectorKeys(keys []VectorKey, time float32, fallback glm.Vec3) glm.Vec3 {
+	if len(keys) == 0 {
+		return fallback
+	}
+	i, factor := findKey(len(keys), func(i int) float32 { return keys[i].Time }, time)
+	if factor == 0 {
+		return keys[i].Value
+	}
+	return lerpVec3(keys[i].Value, keys[i+1].Value, factor)
+}
+
+func sampleQuatKeys(keys []QuatKey, time float32) glm.Quat {
+	if len(keys) == 0 {
+		return glm.QuatIdent()
+	}
+	i, factor := findKey(len(keys), func(i int) float32 { return keys[i].Time }, time)
+	if factor == 0 {
+		return keys[i].Value
+	}
+	return glm.QuatSlerp(keys[i].Value, keys[i+1].Value, factor).Normalize()
+}
+
+func lerpVec3(a, b glm.Vec3, factor float32) glm.Vec3 {
+	return a.Add(b.Sub(a).Mul(factor))
+}
+
+// It converts the seconds played of the clip into ticks, wrapping around if it loops or stopping at the end if it doesn't
+func (c *AnimationClip) ticks(seconds float32, loop bool) float32 {
+	ticks := seconds * c.TicksPerSecond
+	if c.Duration <= 0 {
+		return 0
+	}
+	if loop {
+		return float32(math.Mod(float64(ticks), float64(c.Duration)))
+	}
+	return min(ticks, c.Duration)
+}
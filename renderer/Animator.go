@@ -0,0 +1,149 @@
+package renderer
+
+import (
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Clip being played by an animator
+type AnimationState struct {
+	Clip *AnimationClip
+	// Seconds played of the clip
+	Time  float32
+	Loop  bool
+	Speed float32
+}
+
+func (s *AnimationState) channel(name string) (*Channel, float32) {
+	return s.Clip.Channels[name], s.Clip.ticks(s.Time, s.Loop)
+}
+
+// Animator plays up to two clips on the skeleton of a model and computes the bone matrices for the skinning shader
+type Animator struct {
+	model *Model
+
+	Current *AnimationState
+	// Clip blended on top of the current one, weight 0 is only the current clip and 1 only the next one
+	Next        *AnimationState
+	BlendWeight float32
+	// Seconds that a cross fade takes, it is 0 if the blend weight is set by hand
+	fadeDuration float32
+
+	Paused   bool
+	matrices []glm.Mat4
+}
+
+// It creates the animator of the model, which starts in the bind pose. The model uploads its matrices when it is drawn
+func NewAnimator(model *Model) *Animator {
+	a := &Animator{model: model}
+	if model.Skeleton != nil {
+		a.matrices = model.Skeleton.BindPose()
+	}
+	model.Animator = a
+	return a
+}
+
+// It starts playing the clip from the beginning, stopping any blend
+func (a *Animator) Play(clip *AnimationClip, loop bool) {
+	a.Current = &AnimationState{Clip: clip, Loop: loop, Speed: 1}
+	a.Next = nil
+	a.BlendWeight = 0
+	a.fadeDuration = 0
+	a.Paused = false
+}
+
+func (a *Animator) Pause() {
+	a.Paused = true
+}
+
+func (a *Animator) Resume() {
+	a.Paused = false
+}
+
+// It starts the clip and blends it in over duration seconds, then it becomes the current one
+func (a *Animator) CrossFade(clip *AnimationClip, loop bool, duration float32) {
+	if a.Current == nil || duration <= 0 {
+		a.Play(clip, loop)
+		return
+	}
+	a.Next = &AnimationState{Clip: clip, Loop: loop, Speed: 1}
+	a.BlendWeight = 0
+	a.fadeDuration = duration
+}
+
+// It plays the clip together with the current one with a fixed weight, until Play or CrossFade are called
+func (a *Animator) Blend(clip *AnimationClip, loop bool, weight float32) {
+	a.Next = &AnimationState{Clip: clip, Loop: loop, Speed: 1}
+	a.BlendWeight = glm.Clamp(weight, 0, 1)
+	a.fadeDuration = 0
+}
+
+// It advances the clips and recomputes the bone matrices, it must be called once per frame
+func (a *Animator) Update(deltaTime float32) {
+	if a.Paused || a.Current == nil || a.model.Skeleton == nil {
+		return
+	}
+	a.Current.Time += deltaTime * a.Current.Speed
+	if a.Next != nil {
+		a.Next.Time += deltaTime * a.Next.Speed
+		// When the cross fade ends the next clip replaces the current one
+		if a.fadeDuration > 0 {
+			a.BlendWeight += deltaTime / a.fadeDuration
+			if a.BlendWeight >= 1 {
+				a.Current = a.Next
+				a.Next = nil
+				a.BlendWeight = 0
+				a.fadeDuration = 0
+			}
+		}
+	}
+
+	a.computeBoneMatrices(a.model.Root, glm.Ident4())
+}
+
+// It returns the matrices that move the vertices from the bind pose to the current pose, indexed by bone
+func (a *Animator) BoneMatrices() []glm.Mat4 {
+	return a.matrices
+}
+
+func (a *Animator) computeBoneMatrices(node *Node, parent glm.Mat4) {
+	local := node.Local
+	if p, ok := a.nodePose(node); ok {
+		local = p.matrix()
+	}
+	global := parent.Mul4(local)
+
+	skeleton := a.model.Skeleton
+	if i := skeleton.BoneIndex(node.Name); i >= 0 {
+		a.matrices[i] = global.Mul4(skeleton.Bones[i].Offset)
+	}
+	for _, child := range node.Children {
+		a.computeBoneMatrices(child, global)
+	}
+}
+
+// It samples the node in the clips being played. A clip that doesn't animate the node holds its local pose,
+// so a node that only one of the clips animates also fades in and out with the blend weight
+func (a *Animator) nodePose(node *Node) (pose, bool) {
+	current, currentTime := a.Current.channel(node.Name)
+	var next *Channel
+	var nextTime float32
+	if a.Next != nil && a.BlendWeight > 0 {
+		next, nextTime = a.Next.channel(node.Name)
+	}
+	if current == nil && next == nil {
+		return pose{}, false
+	}
+
+	if a.Next == nil || a.BlendWeight == 0 {
+		return current.sample(currentTime), true
+	}
+	from := poseFromMatrix(node.Local)
+	if current != nil {
+		from = current.sample(currentTime)
+	}
+	to := poseFromMatrix(node.Local)
+	if next != nil {
+		to = next.sample(nextTime)
+	}
+	return blendPoses(from, to, a.BlendWeight), true
+}
@@ -0,0 +1,87 @@
+package renderer
+
+import (
+	"math"
+
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Axis-aligned bounding box
+type AABB struct {
+	Min glm.Vec3
+	Max glm.Vec3
+}
+
+// It returns a box that contains nothing, extending it with a point gives the box of that point
+func EmptyAABB() AABB {
+	inf := float32(math.Inf(1))
+	return AABB{Min: glm.Vec3{inf, inf, inf}, Max: glm.Vec3{-inf, -inf, -inf}}
+}
+
+func (b AABB) IsEmpty() bool {
+	return b.Min[0] > b.Max[0] || b.Min[1] > b.Max[1] || b.Min[2] > b.Max[2]
+}
+
+// It returns the box grown to contain the point
+func (b AABB) Extend(p glm.Vec3) AABB {
+	for i := 0; i < 3; i++ {
+		b.Min[i] = min(b.Min[i], p[i])
+		b.Max[i] = max(b.Max[i], p[i])
+	}
+	return b
+}
+
+// It returns the box that contains both boxes
+func (b AABB) Union(other AABB) AABB {
+	if other.IsEmpty() {
+		return b
+	}
+	return b.Extend(other.Min).Extend(other.Max)
+}
+
+func (b AABB) Center() glm.Vec3 {
+	return b.Min.Add(b.Max).Mul(0.5)
+}
+
+// Half of the size of the box in each axis
+func (b AABB) Extents() glm.Vec3 {
+	return b.Max.Sub(b.Min).Mul(0.5)
+}
+
+// It returns the box that contains this box after the transformation. Each column of the matrix moves the
+// box in its axis, so we add the smaller and the bigger end of each one instead of transforming the eight corners
+func (b AABB) Transform(m glm.Mat4) AABB {
+	if b.IsEmpty() {
+		return b
+	}
+	translation := m.Col(3).Vec3()
+	result := AABB{Min: translation, Max: translation}
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			e := m.At(row, col) * b.Min[col]
+			f := m.At(row, col) * b.Max[col]
+			result.Min[row] += min(e, f)
+			result.Max[row] += max(e, f)
+		}
+	}
+	return result
+}
+
+// Sphere that contains the box, centered on it
+func (b AABB) BoundingSphere() Sphere {
+	return Sphere{Center: b.Center(), Radius: b.Extents().Len()}
+}
+
+type Sphere struct {
+	Center glm.Vec3
+	Radius float32
+}
+
+// It returns the box of the positions of the vertices
+func verticesBounds(vertices []Vertex) AABB {
+	bounds := EmptyAABB()
+	for i := range vertices {
+		bounds = bounds.Extend(vertices[i].Position)
+	}
+	return bounds
+}
@@ -3,11 +3,33 @@ package renderer
 import (
 	"math"
 
+	"github.com/go-gl/gl/v3.3-core/gl"
 	glm "github.com/go-gl/mathgl/mgl32"
 )
 
 type CameraMovement int
 
+// How the input moves the camera
+type CameraMode int
+
+const (
+	// It flies around and looks from its position
+	FPSMode CameraMode = iota
+	// It rotates around the target, to inspect a model
+	OrbitMode
+)
+
+// How the camera projects the scene on the screen
+type ProjectionMode int
+
+const (
+	// Further objects look smaller, Zoom is the vertical field of view
+	Perspective ProjectionMode = iota
+	// Objects keep their size at any distance, OrthoSize is half of the height that is seen. For the top-down tools
+	// and the shadow cameras
+	Orthographic
+)
+
 const (
 	Forward CameraMovement = iota
 	Backward
@@ -15,6 +37,9 @@ const (
 	Right
 	Up
 	Down
+	// Only in the free look, it rotates around the front vector
+	RollLeft
+	RollRight
 )
 
 const (
@@ -22,7 +47,29 @@ const (
 	Pitch       = float32(0.)
 	Speed       = float32(2.5)
 	Sensitivity = float32(0.1)
-	Zoom        = float32(45.)
+	// How fast the velocity reaches the one of the keys and how fast it stops without them, the fraction left
+	// after a second is e^-rate. The sprint multiplies the speed
+	Acceleration     = float32(12.)
+	Damping          = float32(8.)
+	SprintMultiplier = float32(3.)
+	// Seconds that the rotation of the mouse takes to catch up, 0 applies it at once
+	MouseSmoothing = float32(0.03)
+	// Degrees per second of the roll of the free look
+	RollSpeed = float32(90.)
+	Zoom      = float32(45.)
+	// Distances of the near and far planes of the projection
+	NearPlane = float32(0.1)
+	FarPlane  = float32(100.)
+	// Aspect ratio until the size of the viewport is known, and half of the height seen by the orthographic projection
+	Aspect    = float32(16. / 9.)
+	OrthoSize = float32(5.)
+
+	// Defaults of the orbit mode, the pan moves this fraction of the distance to the target per pixel
+	OrbitDistance    = float32(5.)
+	MinOrbitDistance = float32(0.05)
+	PanSensitivity   = float32(0.002)
+	// Fraction of the distance that each step of the scroll wheel gets closer
+	DollyStep = float32(0.1)
 )
 
 type Camera struct {
@@ -38,6 +85,46 @@ type Camera struct {
 	MovementSpeed    float32
 	MouseSensitivity float32
 	Zoom             float32
+
+	// The keys set the velocity that the camera reaches with Acceleration, and it slows down with Damping when they are released.
+	// Sprint is held by the input to go faster
+	Velocity         glm.Vec3
+	Acceleration     float32
+	Damping          float32
+	Sprint           bool
+	SprintMultiplier float32
+	MouseSmoothing   float32
+
+	// In the free look the rotation is a quaternion instead of the yaw and the pitch, so it can look straight up,
+	// go over the top and roll. The movement follows the front and the up of the camera
+	FreeLook    bool
+	Orientation glm.Quat
+
+	// Input of the frame that Update applies, the direction of the keys, the roll, and the rotation of the mouse that is left
+	moveInput      glm.Vec3
+	rollInput      float32
+	pendingYaw     float32
+	pendingPitch   float32
+	constrainPitch bool
+
+	Mode CameraMode
+	// Point that the orbit mode rotates around and how far the camera is from it
+	Target   glm.Vec3
+	Distance float32
+
+	// The lens of the camera, Zoom is the field of view of the perspective projection
+	Projection ProjectionMode
+	Near       float32
+	Far        float32
+	// Width divided by height of the viewport, the framebuffer resize callback keeps it updated with SetAspect
+	Aspect    float32
+	OrthoSize float32
+	// The depth goes from 1 at the near plane to 0 at the far one, the floats have more precision near 0 so it
+	// compensates the loss of precision of the perspective in the distance. It only works with glClipControl, see
+	// ClipControlSupported, and a framebuffer with FloatDepth. ApplyDepthState sets the state it needs
+	ReversedZ bool
+	// The perspective projection has no far plane, Far is ignored. The orthographic projection can't be infinite
+	InfiniteFar bool
 }
 
 func NewCam(position glm.Vec3, up glm.Vec3, yaw float32, pitch float32) *Camera {
@@ -62,8 +149,18 @@ func NewCam(position glm.Vec3, up glm.Vec3, yaw float32, pitch float32) *Camera
 		MovementSpeed:    Speed,
 		MouseSensitivity: Sensitivity,
 		Zoom:             Zoom,
+		Acceleration:     Acceleration,
+		Damping:          Damping,
+		SprintMultiplier: SprintMultiplier,
+		MouseSmoothing:   MouseSmoothing,
+		Distance:         OrbitDistance,
+		Near:             NearPlane,
+		Far:              FarPlane,
+		Aspect:           Aspect,
+		OrthoSize:        OrthoSize,
 	}
 	c.updateCameraVectors()
+	c.Target = c.Position.Add(c.Front.Mul(c.Distance))
 
 	return c
 }
@@ -86,35 +183,183 @@ func (c *Camera) updateCameraVectors() {
 	c.Up = c.Right.Cross(c.Front).Normalize()
 }
 
-// Process Keyboard from the user
+// The vectors of the free look are the axes of the camera rotated by the orientation, the camera looks down its -z
+func (c *Camera) updateOrientationVectors() {
+	c.Orientation = c.Orientation.Normalize()
+	c.Front = c.Orientation.Rotate(glm.Vec3{0, 0, -1})
+	c.Up = c.Orientation.Rotate(glm.Vec3{0, 1, 0})
+	c.Right = c.Orientation.Rotate(glm.Vec3{1, 0, 0})
+}
+
+// It switches the free look, keeping where the camera looks. Leaving it drops the roll and brings the pitch back into ±89°
+func (c *Camera) SetFreeLook(freeLook bool) {
+	if freeLook == c.FreeLook {
+		return
+	}
+	c.FreeLook = freeLook
+	if freeLook {
+		// The columns of the rotation are the axes of the camera
+		c.Orientation = glm.Mat4ToQuat(glm.Mat4{
+			c.Right[0], c.Right[1], c.Right[2], 0,
+			c.Up[0], c.Up[1], c.Up[2], 0,
+			-c.Front[0], -c.Front[1], -c.Front[2], 0,
+			0, 0, 0, 1,
+		})
+		c.updateOrientationVectors()
+		return
+	}
+	c.Yaw = glm.RadToDeg(float32(math.Atan2(float64(c.Front[2]), float64(c.Front[0]))))
+	c.Pitch = max(min(glm.RadToDeg(float32(math.Asin(float64(max(min(c.Front[1], 1), -1))))), 89.), -89.)
+	c.updateCameraVectors()
+}
+
+// It changes how the input moves the camera. The orbit mode starts around the point in front of the camera at Distance,
+// so the view doesn't jump
+func (c *Camera) SetMode(mode CameraMode) {
+	if mode == OrbitMode && c.Mode != OrbitMode {
+		// The orbit goes around the world up, so it uses the yaw and the pitch
+		c.SetFreeLook(false)
+		c.Velocity = glm.Vec3{}
+		c.Target = c.Position.Add(c.Front.Mul(c.Distance))
+	}
+	c.Mode = mode
+}
+
+// Process Keyboard from the user, in the orbit mode it pans and dollies instead of flying
 func (c *Camera) ProcessKeyBoard(direction CameraMovement, deltaTime float32) {
 	velocity := c.MovementSpeed * deltaTime
 
+	if c.Mode == OrbitMode {
+		// At the default distance the keys pan at the movement speed, closer to the target they go slower like the mouse
+		pixels := velocity / (PanSensitivity * OrbitDistance)
+		switch direction {
+		case Forward:
+			c.Dolly(velocity)
+		case Backward:
+			c.Dolly(-velocity)
+		case Right:
+			c.Pan(pixels, 0)
+		case Left:
+			c.Pan(-pixels, 0)
+		case Up:
+			c.Pan(0, pixels)
+		case Down:
+			c.Pan(0, -pixels)
+		}
+		return
+	}
+
+	// The fly mode only gathers the keys of the frame, Update turns them into the velocity
 	switch direction {
 	case Forward:
-		c.Position = c.Position.Add(glm.Vec3{c.Front[0] * velocity, 0.0, c.Front[2] * velocity})
+		c.moveInput = c.moveInput.Add(c.forward())
 	case Backward:
-		c.Position = c.Position.Sub(glm.Vec3{c.Front[0] * velocity, 0.0, c.Front[2] * velocity})
+		c.moveInput = c.moveInput.Sub(c.forward())
 	case Right:
-		c.Position = c.Position.Add(glm.Vec3{c.Right[0] * velocity, 0.0, c.Right[2] * velocity})
+		c.moveInput = c.moveInput.Add(c.right())
 	case Left:
-		c.Position = c.Position.Sub(glm.Vec3{c.Right[0] * velocity, 0.0, c.Right[2] * velocity})
+		c.moveInput = c.moveInput.Sub(c.right())
 	case Up:
-		c.Position = c.Position.Add(glm.Vec3{0.0, c.Up[1] * velocity, 0.0})
+		c.moveInput = c.moveInput.Add(c.up())
 	case Down:
-		c.Position = c.Position.Sub(glm.Vec3{0.0, c.Up[1] * velocity, 0.0})
+		c.moveInput = c.moveInput.Sub(c.up())
+	case RollLeft:
+		c.rollInput--
+	case RollRight:
+		c.rollInput++
 	}
 }
 
-// Process the mouse movement to rotate the camera
-func (c *Camera) ProcessMouseMovement(xoffset, yoffset float32, constrainPitch bool) {
-	xoffset *= c.MouseSensitivity
-	yoffset *= c.MouseSensitivity
+// Directions of the movement. Out of the free look the camera walks on the horizontal plane and goes up and down
+// along the world up, whatever its pitch
+func (c *Camera) forward() glm.Vec3 {
+	if c.FreeLook {
+		return c.Front
+	}
+	return horizontal(c.Front)
+}
+
+func (c *Camera) right() glm.Vec3 {
+	if c.FreeLook {
+		return c.Right
+	}
+	return horizontal(c.Right)
+}
+
+func (c *Camera) up() glm.Vec3 {
+	if c.FreeLook {
+		return c.Up
+	}
+	return c.WorldUp.Normalize()
+}
 
-	c.Yaw += xoffset
-	c.Pitch += yoffset
+func horizontal(v glm.Vec3) glm.Vec3 {
+	v[1] = 0
+	if v.Len() == 0 {
+		return v
+	}
+	return v.Normalize()
+}
 
-	if constrainPitch {
+// It moves the camera with the keys and the mouse gathered since the last frame, it must be called once per frame
+func (c *Camera) Update(deltaTime float32) {
+	input := c.moveInput
+	roll := c.rollInput
+	c.moveInput = glm.Vec3{}
+	c.rollInput = 0
+
+	if c.Mode == FPSMode {
+		// The speed is the same in the diagonals
+		target := glm.Vec3{}
+		rate := c.Damping
+		if input.Len() > 0 {
+			speed := c.MovementSpeed
+			if c.Sprint {
+				speed *= c.SprintMultiplier
+			}
+			target = input.Normalize().Mul(speed)
+			rate = c.Acceleration
+		}
+		// Exponential approach to the target, it doesn't depend on the frame rate
+		keep := float32(math.Exp(float64(-rate * deltaTime)))
+		c.Velocity = target.Add(c.Velocity.Sub(target).Mul(keep))
+		if target.Len() == 0 && c.Velocity.Len() < 1e-3 {
+			c.Velocity = glm.Vec3{}
+		}
+		c.Position = c.Position.Add(c.Velocity.Mul(deltaTime))
+
+		if c.FreeLook && roll != 0 {
+			c.rotate(0, 0, roll*RollSpeed*deltaTime)
+		}
+	}
+
+	// The smoothed mouse applies a part of the rotation that is left each frame, with the same exponential approach
+	if c.pendingYaw != 0 || c.pendingPitch != 0 {
+		fraction := float32(1)
+		if c.MouseSmoothing > 0 {
+			fraction = 1 - float32(math.Exp(float64(-deltaTime/c.MouseSmoothing)))
+		}
+		yaw, pitch := c.pendingYaw*fraction, c.pendingPitch*fraction
+		c.pendingYaw -= yaw
+		c.pendingPitch -= pitch
+		if math.Abs(float64(c.pendingYaw)) < 1e-4 && math.Abs(float64(c.pendingPitch)) < 1e-4 {
+			yaw, pitch = yaw+c.pendingYaw, pitch+c.pendingPitch
+			c.pendingYaw, c.pendingPitch = 0, 0
+		}
+		c.look(yaw, pitch)
+	}
+}
+
+// It turns the camera in degrees, in the free look around its own axes
+func (c *Camera) look(yaw, pitch float32) {
+	if c.FreeLook {
+		c.rotate(yaw, pitch, 0)
+		return
+	}
+	c.Yaw += yaw
+	c.Pitch += pitch
+
+	if c.constrainPitch {
 		if c.Pitch > 89.0 {
 			c.Pitch = 89.0
 		}
@@ -125,8 +370,40 @@ func (c *Camera) ProcessMouseMovement(xoffset, yoffset float32, constrainPitch b
 	c.updateCameraVectors()
 }
 
-// Process input from the mouse scroll to zoom
+// It rotates the orientation of the free look in degrees around the up, the right and the front of the camera.
+// Turning right is a negative rotation around the up, and rolling right a positive one around the front
+func (c *Camera) rotate(yaw, pitch, roll float32) {
+	rotation := glm.QuatRotate(glm.DegToRad(-yaw), glm.Vec3{0, 1, 0}).
+		Mul(glm.QuatRotate(glm.DegToRad(pitch), glm.Vec3{1, 0, 0})).
+		Mul(glm.QuatRotate(glm.DegToRad(roll), glm.Vec3{0, 0, -1}))
+	// Multiplied on the right, the axes are the ones of the camera and not the ones of the world
+	c.Orientation = c.Orientation.Mul(rotation)
+	c.updateOrientationVectors()
+}
+
+// Process the mouse movement to rotate the camera, in the orbit mode it rotates around the target at once
+func (c *Camera) ProcessMouseMovement(xoffset, yoffset float32, constrainPitch bool) {
+	if c.Mode == OrbitMode {
+		c.Orbit(xoffset, yoffset)
+		return
+	}
+	// The rotation waits for Update, which smooths it. The free look has no poles, so it never constrains the pitch
+	c.pendingYaw += xoffset * c.MouseSensitivity
+	c.pendingPitch += yoffset * c.MouseSensitivity
+	c.constrainPitch = constrainPitch
+}
+
+// Process input from the mouse scroll to zoom, in the orbit mode it gets closer to the target instead.
+// The orthographic projection zooms by seeing a smaller area, the distance doesn't change the size of the objects
 func (c *Camera) ProcessMouseScroll(yoffset float32) {
+	if c.Projection == Orthographic {
+		c.OrthoSize *= float32(math.Pow(float64(1-DollyStep), float64(yoffset)))
+		return
+	}
+	if c.Mode == OrbitMode {
+		c.Dolly(yoffset)
+		return
+	}
 	c.Zoom -= yoffset
 	if c.Zoom < 1.0 {
 		c.Zoom = 1.0
@@ -136,7 +413,180 @@ func (c *Camera) ProcessMouseScroll(yoffset float32) {
 	}
 }
 
+// It rotates the camera around the target, the offsets are in pixels like the ones of ProcessMouseMovement
+func (c *Camera) Orbit(xoffset, yoffset float32) {
+	c.Yaw += xoffset * c.MouseSensitivity
+	// The pitch can't reach the poles, the up vector would flip there
+	c.Pitch = max(min(c.Pitch+yoffset*c.MouseSensitivity, 89.), -89.)
+	c.updateCameraVectors()
+	c.Position = c.Target.Sub(c.Front.Mul(c.Distance))
+}
+
+// It moves the camera and the target in the plane of the screen, further targets move more per pixel so they follow the cursor
+func (c *Camera) Pan(xoffset, yoffset float32) {
+	scale := PanSensitivity * c.Distance
+	offset := c.Right.Mul(-xoffset * scale).Add(c.Up.Mul(-yoffset * scale))
+	c.Target = c.Target.Add(offset)
+	c.Position = c.Position.Add(offset)
+}
+
+// It moves the camera towards the target, each step a fraction of the distance so it never goes through it
+func (c *Camera) Dolly(steps float32) {
+	c.Distance *= float32(math.Pow(float64(1-DollyStep), float64(steps)))
+	c.Distance = max(c.Distance, MinOrbitDistance)
+	c.Position = c.Target.Sub(c.Front.Mul(c.Distance))
+}
+
+// It looks at the center of the box from the distance where it fits in the field of view, keeping the direction of the camera
+func (c *Camera) Focus(box AABB) {
+	if box.IsEmpty() {
+		return
+	}
+	sphere := box.BoundingSphere()
+	halfFov := float64(glm.DegToRad(c.Zoom)) / 2
+	c.Target = sphere.Center
+	c.Distance = max(sphere.Radius/float32(math.Sin(halfFov)), MinOrbitDistance)
+	c.Position = c.Target.Sub(c.Front.Mul(c.Distance))
+	// The orthographic projection has to see the diameter in the height and in the width
+	if c.Aspect > 0 {
+		c.OrthoSize = max(sphere.Radius, sphere.Radius/c.Aspect)
+	}
+}
+
 // To get the view matrix
 func (c *Camera) GetViewMatrix() glm.Mat4 {
 	return glm.LookAtV(c.Position, c.Position.Add(c.Front), c.Up)
 }
+
+// It updates the aspect ratio with the size of the viewport, a minimized window keeps the previous one
+func (c *Camera) SetAspect(width, height int) {
+	if width > 0 && height > 0 {
+		c.Aspect = float32(width) / float32(height)
+	}
+}
+
+// Projection matrix of the lens of the camera, with its aspect ratio
+func (c *Camera) ProjectionMatrix() glm.Mat4 {
+	return c.projection(c.Aspect)
+}
+
+// Projection × view, what the frustum and the shaders that don't need them apart use
+func (c *Camera) ViewProjection() glm.Mat4 {
+	return c.ProjectionMatrix().Mul4(c.GetViewMatrix())
+}
+
+// Projection of the camera for a viewport with the given aspect ratio
+func (c *Camera) projection(aspect float32) glm.Mat4 {
+	var projection glm.Mat4
+	switch {
+	case c.Projection == Orthographic:
+		halfWidth := c.OrthoSize * aspect
+		projection = glm.Ortho(-halfWidth, halfWidth, -c.OrthoSize, c.OrthoSize, c.Near, c.Far)
+	case c.InfiniteFar:
+		// The limit of the perspective matrix when the far plane goes to infinity
+		f := 1 / float32(math.Tan(float64(glm.DegToRad(c.Zoom))/2))
+		projection = glm.Mat4{
+			f / aspect, 0, 0, 0,
+			0, f, 0, 0,
+			0, 0, -1, -1,
+			0, 0, -2 * c.Near, 0,
+		}
+	default:
+		projection = glm.Perspective(glm.DegToRad(c.Zoom), aspect, c.Near, c.Far)
+	}
+	if c.ReversedZ {
+		// The clip control takes the z of clip space from 0 to w as the depth, instead of from -w to w. The new z is
+		// (w - z) / 2, which swaps the near plane and the far one. With the infinite far plane it is exactly the near distance
+		projection = glm.Mat4{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -0.5, 0,
+			0, 0, 0.5, 1,
+		}.Mul4(projection)
+	}
+	return projection
+}
+
+// Planes of the view of the camera. They are taken from the usual projection, the reversed depth has the near plane
+// at z = w and the far one at z = 0, not between -w and w like NewFrustum expects
+func (c *Camera) Frustum() Frustum {
+	lens := *c
+	lens.ReversedZ = false
+	return NewFrustum(lens.ViewProjection())
+}
+
+// Depth test and cleared depth that the projection needs, the reversed depth keeps what is bigger
+func (c *Camera) DepthFunc() uint32 {
+	if c.ReversedZ {
+		return gl.GREATER
+	}
+	return gl.LESS
+}
+
+func (c *Camera) ClearDepth() float64 {
+	if c.ReversedZ {
+		return 0
+	}
+	return 1
+}
+
+// It sets the depth test, the cleared depth and the clip control for the projection of the camera, before clearing its framebuffer
+func (c *Camera) ApplyDepthState() {
+	if c.ReversedZ {
+		gl.ClipControl(gl.LOWER_LEFT, gl.ZERO_TO_ONE)
+		clipZeroToOne = true
+	}
+	gl.ClearDepth(c.ClearDepth())
+	gl.DepthFunc(c.DepthFunc())
+}
+
+// It goes back to the default depth state, the shadow maps and the other passes expect it
+func ResetDepthState() {
+	// glClipControl may not exist, it is only called back if ApplyDepthState needed it
+	if clipZeroToOne {
+		gl.ClipControl(gl.LOWER_LEFT, gl.NEGATIVE_ONE_TO_ONE)
+		clipZeroToOne = false
+	}
+	gl.ClearDepth(1)
+	gl.DepthFunc(gl.LESS)
+}
+
+// If the clip control of the reversed depth is set
+var clipZeroToOne bool
+
+// It tells if the context has glClipControl, from OpenGL 4.5 or the ARB_clip_control extension. Without it the
+// reversed depth can't be used, the depth of clip space would still go from -1 to 1 and lose the precision it gains
+func ClipControlSupported() bool {
+	var major, minor int32
+	gl.GetIntegerv(gl.MAJOR_VERSION, &major)
+	gl.GetIntegerv(gl.MINOR_VERSION, &minor)
+	if major > 4 || (major == 4 && minor >= 5) {
+		return true
+	}
+	var count int32
+	gl.GetIntegerv(gl.NUM_EXTENSIONS, &count)
+	for i := int32(0); i < count; i++ {
+		if gl.GoStr(gl.GetStringi(gl.EXTENSIONS, uint32(i))) == "GL_ARB_clip_control" {
+			return true
+		}
+	}
+	return false
+}
+
+// It returns the ray that starts at the camera and goes through the point of the viewport, in pixels from its top left corner
+func (c *Camera) ScreenPointToRay(x, y, width, height float32) Ray {
+	// From pixels to normalized device coordinates, where y goes up
+	ndcX := 2*x/width - 1
+	ndcY := 1 - 2*y/height
+
+	// The second point is in the middle of the depth instead of on the far plane, which is at infinity
+	// without one. The orthographic rays start on the near plane under the cursor, all parallel
+	nearZ, middleZ := float32(-1), float32(0)
+	if c.ReversedZ {
+		nearZ, middleZ = 1, 0.5
+	}
+	inverse := c.projection(width / height).Mul4(c.GetViewMatrix()).Inv()
+	near := glm.TransformCoordinate(glm.Vec3{ndcX, ndcY, nearZ}, inverse)
+	middle := glm.TransformCoordinate(glm.Vec3{ndcX, ndcY, middleZ}, inverse)
+	return Ray{Origin: near, Direction: middle.Sub(near).Normalize()}
+}
@@ -0,0 +1,155 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+var (
+	ErrGoldenMismatch = errors.New("image differs from the golden image")
+	ErrImageSize      = errors.New("images have different sizes")
+)
+
+// It reads the color of the framebuffer into an image, or of the window of the given size if it is nil.
+// OpenGL starts at the bottom row and the images at the top one, so it is flipped like the textures when they are loaded
+func CaptureFramebuffer(f *Framebuffer, width, height int32) *image.RGBA {
+	if f != nil {
+		gl.BindFramebuffer(gl.READ_FRAMEBUFFER, f.FBO)
+		gl.ReadBuffer(gl.COLOR_ATTACHMENT0)
+		width, height = f.Width, f.Height
+	} else {
+		gl.BindFramebuffer(gl.READ_FRAMEBUFFER, 0)
+		gl.ReadBuffer(gl.BACK)
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	// The rows of the image are not padded
+	gl.PixelStorei(gl.PACK_ALIGNMENT, 1)
+	gl.ReadPixels(0, 0, width, height, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(rgba.Pix))
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, 0)
+
+	flipVertical(rgba)
+	return rgba
+}
+
+func SavePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func LoadPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return png.Decode(file)
+}
+
+// Result of comparing two images
+type ImageDiff struct {
+	// Pixels with a channel that differs more than the tolerance
+	Mismatched int
+	Total      int
+	// Biggest difference of a channel in all the image
+	MaxDifference uint8
+	// The mismatched pixels in red over the dimmed expected image, to see where they are
+	Diff *image.RGBA
+}
+
+func (d ImageDiff) MismatchRatio() float64 {
+	if d.Total == 0 {
+		return 0
+	}
+	return float64(d.Mismatched) / float64(d.Total)
+}
+
+// It compares the images pixel by pixel, a pixel is mismatched if any of its channels differs more than the tolerance.
+// The software renderers round differently than the GPUs, so a small tolerance is usually needed
+func CompareImages(got, want image.Image, tolerance uint8) (ImageDiff, error) {
+	bounds := want.Bounds()
+	if got.Bounds().Dx() != bounds.Dx() || got.Bounds().Dy() != bounds.Dy() {
+		return ImageDiff{}, fmt.Errorf("%w: got %v, want %v", ErrImageSize, got.Bounds().Size(), bounds.Size())
+	}
+	offset := got.Bounds().Min.Sub(bounds.Min)
+
+	diff := ImageDiff{Total: bounds.Dx() * bounds.Dy(), Diff: image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			g := color.RGBAModel.Convert(got.At(x+offset.X, y+offset.Y)).(color.RGBA)
+			w := color.RGBAModel.Convert(want.At(x, y)).(color.RGBA)
+			d := max(absDiff(g.R, w.R), absDiff(g.G, w.G), absDiff(g.B, w.B), absDiff(g.A, w.A))
+			diff.MaxDifference = max(diff.MaxDifference, d)
+
+			out := color.RGBA{w.R / 4, w.G / 4, w.B / 4, 255}
+			if d > tolerance {
+				diff.Mismatched++
+				out = color.RGBA{255, 0, 0, 255}
+			}
+			diff.Diff.SetRGBA(x-bounds.Min.X, y-bounds.Min.Y, out)
+		}
+	}
+	return diff, nil
+}
+
+// Options of CompareGolden
+type GoldenOptions struct {
+	// Difference allowed in each channel of a pixel
+	Tolerance uint8
+	// Fraction of the pixels that can be mismatched, from 0 to 1
+	MaxMismatchRatio float64
+	// It writes the image as the new golden instead of comparing, to accept an intended change
+	Update bool
+}
+
+// Helper for the visual tests, it compares the image with the golden PNG at the path. When they differ it saves
+// the image and the diff next to the golden with the .got.png and .diff.png suffixes, and returns ErrGoldenMismatch
+func CompareGolden(goldenPath string, img image.Image, options GoldenOptions) error {
+	if options.Update {
+		return SavePNG(goldenPath, img)
+	}
+	want, err := LoadPNG(goldenPath)
+	if err != nil {
+		return fmt.Errorf("failed to load the golden image: %w", err)
+	}
+	diff, err := CompareImages(img, want, options.Tolerance)
+	if err != nil {
+		return err
+	}
+	if diff.MismatchRatio() <= options.MaxMismatchRatio {
+		return nil
+	}
+
+	base := goldenPath[:len(goldenPath)-len(filepath.Ext(goldenPath))]
+	if err := SavePNG(base+".got.png", img); err != nil {
+		return err
+	}
+	if err := SavePNG(base+".diff.png", diff.Diff); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s, %d of %d pixels (%.3f%%) differ more than %d, the maximum difference is %d",
+		ErrGoldenMismatch, goldenPath, diff.Mismatched, diff.Total, diff.MismatchRatio()*100, options.Tolerance, diff.MaxDifference)
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,111 @@
+package renderer
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+)
+
+func filledImage(width, height int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCompareImagesTolerance(t *testing.T) {
+	want := filledImage(4, 4, color.RGBA{100, 100, 100, 255})
+	tests := []struct {
+		name       string
+		got        color.RGBA
+		tolerance  uint8
+		mismatched int
+		maxDiff    uint8
+	}{
+		{"equal", color.RGBA{100, 100, 100, 255}, 0, 0, 0},
+		{"within tolerance", color.RGBA{102, 98, 100, 255}, 2, 0, 2},
+		{"beyond tolerance", color.RGBA{103, 100, 100, 255}, 2, 16, 3},
+		{"alpha counts", color.RGBA{100, 100, 100, 250}, 2, 16, 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff, err := CompareImages(filledImage(4, 4, test.got), want, test.tolerance)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.Mismatched != test.mismatched || diff.Total != 16 || diff.MaxDifference != test.maxDiff {
+				t.Errorf("got %d of %d mismatched with a maximum difference of %d, want %d of 16 and %d",
+					diff.Mismatched, diff.Total, diff.MaxDifference, test.mismatched, test.maxDiff)
+			}
+		})
+	}
+}
+
+func TestCompareImagesSize(t *testing.T) {
+	_, err := CompareImages(filledImage(4, 4, color.RGBA{}), filledImage(4, 3, color.RGBA{}), 0)
+	if !errors.Is(err, ErrImageSize) {
+		t.Errorf("got %v, want %v", err, ErrImageSize)
+	}
+}
+
+func TestCompareImagesDiff(t *testing.T) {
+	want := filledImage(2, 1, color.RGBA{200, 100, 40, 255})
+	got := filledImage(2, 1, color.RGBA{200, 100, 40, 255})
+	got.SetRGBA(1, 0, color.RGBA{0, 0, 0, 255})
+
+	diff, err := CompareImages(got, want, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Mismatched != 1 || diff.MismatchRatio() != 0.5 {
+		t.Errorf("got %d mismatched and a ratio of %v, want 1 and 0.5", diff.Mismatched, diff.MismatchRatio())
+	}
+	// The matching pixels are the expected image dimmed and the mismatched ones are red
+	if c := diff.Diff.RGBAAt(0, 0); c != (color.RGBA{50, 25, 10, 255}) {
+		t.Errorf("got %v for a matching pixel, want the dimmed expected color", c)
+	}
+	if c := diff.Diff.RGBAAt(1, 0); c != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("got %v for a mismatched pixel, want red", c)
+	}
+}
+
+func TestCompareImagesOffset(t *testing.T) {
+	// Only the sizes have to match, the images are compared from their own minimum points
+	want := filledImage(2, 2, color.RGBA{10, 20, 30, 255})
+	got := filledImage(4, 4, color.RGBA{10, 20, 30, 255}).SubImage(image.Rect(2, 2, 4, 4))
+
+	diff, err := CompareImages(got, want, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Mismatched != 0 {
+		t.Errorf("got %d mismatched pixels, want 0", diff.Mismatched)
+	}
+}
+
+func TestCompareGolden(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "frame.png")
+	want := filledImage(4, 4, color.RGBA{100, 100, 100, 255})
+	if err := CompareGolden(golden, want, GoldenOptions{Update: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := filledImage(4, 4, color.RGBA{100, 100, 100, 255})
+	got.SetRGBA(0, 0, color.RGBA{255, 255, 255, 255})
+	if err := CompareGolden(golden, got, GoldenOptions{MaxMismatchRatio: 1.0 / 16}); err != nil {
+		t.Errorf("got %v with one of 16 pixels mismatched, want it accepted", err)
+	}
+	if err := CompareGolden(golden, got, GoldenOptions{}); !errors.Is(err, ErrGoldenMismatch) {
+		t.Errorf("got %v, want %v", err, ErrGoldenMismatch)
+	}
+	for _, suffix := range []string{".got.png", ".diff.png"} {
+		if _, err := LoadPNG(filepath.Join(filepath.Dir(golden), "frame"+suffix)); err != nil {
+			t.Errorf("the %s image was not saved: %v", suffix, err)
+		}
+	}
+}
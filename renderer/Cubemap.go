@@ -0,0 +1,52 @@
+package renderer
+
+import (
+	"github.com/go-gl/gl/v3.3-core/gl"
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Function that creates an empty cubemap with the six faces of the same size and format
+func NewCubemap(size int32, internalFormat int32, format, xtype uint32) uint32 {
+	var textureID uint32
+	gl.GenTextures(1, &textureID)
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, textureID)
+
+	// The faces go one after the other starting from +X, so we can add the index to the first one
+	for i := uint32(0); i < 6; i++ {
+		gl.TexImage2D(gl.TEXTURE_CUBE_MAP_POSITIVE_X+i, 0, internalFormat, size, size, 0, format, xtype, nil)
+	}
+
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_WRAP_R, gl.CLAMP_TO_EDGE)
+
+	return textureID
+}
+
+// Function that creates a cubemap to store depth, used by the shadows of the point lights
+func NewDepthCubemap(size int32) uint32 {
+	textureID := NewCubemap(size, gl.DEPTH_COMPONENT24, gl.DEPTH_COMPONENT, gl.FLOAT)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+	return textureID
+}
+
+// It returns the view matrices that look through each face of a cubemap from the given position, in the order of the faces
+func CubemapFaceViews(position glm.Vec3) [6]glm.Mat4 {
+	// Direction and up vector of each face, following the cubemap conventions
+	faces := [6][2]glm.Vec3{
+		{{1, 0, 0}, {0, -1, 0}},
+		{{-1, 0, 0}, {0, -1, 0}},
+		{{0, 1, 0}, {0, 0, 1}},
+		{{0, -1, 0}, {0, 0, -1}},
+		{{0, 0, 1}, {0, -1, 0}},
+		{{0, 0, -1}, {0, -1, 0}},
+	}
+	var views [6]glm.Mat4
+	for i, face := range faces {
+		views[i] = glm.LookAtV(position, position.Add(face[0]), face[1])
+	}
+	return views
+}
@@ -0,0 +1,358 @@
+package renderer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Texture units of the environment maps, between the material maps and the shadow maps
+const (
+	IrradianceTextureUnit = 6
+	PrefilterTextureUnit  = 7
+	BRDFLUTTextureUnit    = 8
+)
+
+// Sizes of the precomputed maps
+const (
+	EnvironmentCubemapSize = 512
+	IrradianceSize         = 32
+	PrefilterSize          = 128
+	PrefilterMipLevels     = 5
+	BRDFLUTSize            = 512
+)
+
+var ErrInvalidEnvironmentCache = errors.New("invalid environment cache file")
+
+type EnvironmentOptions struct {
+	// Directory of the shaders that precompute the maps, "shaders" if it is empty
+	ShaderDir string
+	// If it isn't empty the precomputed maps are saved there and loaded the next time instead of computing them again
+	CacheDir string
+}
+
+// Image based lighting from an HDR environment. The irradiance is the diffuse light that arrives from every direction,
+// the prefiltered map is the specular light blurred for each roughness in its mips, and the BRDF LUT is the scale and bias of the Fresnel term
+type Environment struct {
+	Cubemap    uint32
+	Irradiance uint32
+	Prefilter  uint32
+	BRDFLUT    uint32
+}
+
+// It loads the equirectangular .hdr image and precomputes all the maps on the GPU, or reads them from the cache
+func NewEnvironment(hdrPath string, options EnvironmentOptions) (*Environment, error) {
+	if options.ShaderDir == "" {
+		options.ShaderDir = "shaders"
+	}
+	// The small mips of the prefiltered map show the edges of the faces without it
+	gl.Enable(gl.TEXTURE_CUBE_MAP_SEAMLESS)
+	cachePath := ""
+	var cacheKey uint64
+	if options.CacheDir != "" {
+		var err error
+		if cacheKey, err = environmentCacheKey(options.ShaderDir); err != nil {
+			return nil, err
+		}
+		cachePath = filepath.Join(options.CacheDir, strings.TrimSuffix(filepath.Base(hdrPath), filepath.Ext(hdrPath))+".ibl")
+		if e, err := loadEnvironmentCache(cachePath, hdrPath, cacheKey); err == nil {
+			return e, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("Warning: ignoring the environment cache %s: %v\n", cachePath, err)
+		}
+	}
+
+	e, err := computeEnvironment(hdrPath, options.ShaderDir)
+	if err != nil {
+		return nil, err
+	}
+	if cachePath != "" {
+		if err := e.saveCache(cachePath, cacheKey); err != nil {
+			fmt.Printf("Warning: the environment cache couldn't be saved: %v\n", err)
+		}
+	}
+	return e, nil
+}
+
+// It binds the maps to their units and enables the image based lighting in the PBR shader.
+// It can be called on a nil environment to disable it, the samplers still need their units so they don't clash with the 2D ones
+func (e *Environment) Apply(shader *Shader) {
+	shader.SetInt("irradianceMap", IrradianceTextureUnit)
+	shader.SetInt("prefilterMap", PrefilterTextureUnit)
+	shader.SetInt("brdfLUT", BRDFLUTTextureUnit)
+	shader.SetBool("useIBL", e != nil)
+	if e == nil {
+		return
+	}
+	shader.SetFloat("prefilterMaxLod", PrefilterMipLevels-1)
+	gl.ActiveTexture(gl.TEXTURE0 + IrradianceTextureUnit)
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, e.Irradiance)
+	gl.ActiveTexture(gl.TEXTURE0 + PrefilterTextureUnit)
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, e.Prefilter)
+	gl.ActiveTexture(gl.TEXTURE0 + BRDFLUTTextureUnit)
+	gl.BindTexture(gl.TEXTURE_2D, e.BRDFLUT)
+	gl.ActiveTexture(gl.TEXTURE0)
+}
+
+func (e *Environment) Delete() {
+	textures := []uint32{e.Cubemap, e.Irradiance, e.Prefilter, e.BRDFLUT}
+	gl.DeleteTextures(int32(len(textures)), &textures[0])
+}
+
+// Vertex and fragment shaders that precompute each map
+var environmentShaders = map[string][2]string{
+	"equirectangular": {"cubemapVShader.glsl", "equirectangularFShader.glsl"},
+	"irradiance":      {"cubemapVShader.glsl", "irradianceFShader.glsl"},
+	"prefilter":       {"cubemapVShader.glsl", "prefilterFShader.glsl"},
+	"brdf":            {"brdfVShader.glsl", "brdfFShader.glsl"},
+}
+
+func computeEnvironment(hdrPath, shaderDir string) (*Environment, error) {
+	hdrTexture, err := HDRTextureFromFile(hdrPath)
+	if err != nil {
+		return nil, err
+	}
+	defer gl.DeleteTextures(1, &hdrTexture)
+
+	shaders := map[string]*Shader{}
+	for name, files := range environmentShaders {
+		shader, err := NewShader(filepath.Join(shaderDir, files[0]), filepath.Join(shaderDir, files[1]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the %s shader: %w", name, err)
+		}
+		defer shader.Delete()
+		shaders[name] = shader
+	}
+
+	// We save the state that we change to restore it at the end
+	var viewport [4]int32
+	gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+	defer gl.Viewport(viewport[0], viewport[1], viewport[2], viewport[3])
+	// The cube is seen from inside
+	gl.DepthFunc(gl.LEQUAL)
+	defer gl.DepthFunc(gl.LESS)
+
+	var fbo, rbo uint32
+	gl.GenFramebuffers(1, &fbo)
+	gl.GenRenderbuffers(1, &rbo)
+	defer gl.DeleteFramebuffers(1, &fbo)
+	defer gl.DeleteRenderbuffers(1, &rbo)
+	defer gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, fbo)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, rbo)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.RENDERBUFFER, rbo)
+
+	e := newEnvironmentTextures()
+
+	// It renders the cube into every face of the mip level of the cubemap
+	projection := glm.Perspective(glm.DegToRad(90.), 1., 0.1, 10.)
+	views := CubemapFaceViews(glm.Vec3{0, 0, 0})
+	renderFaces := func(shader *Shader, cubemap uint32, size int32, level int32) error {
+		gl.RenderbufferStorage(gl.RENDERBUFFER, gl.DEPTH_COMPONENT24, size, size)
+		gl.Viewport(0, 0, size, size)
+		shader.SetMat4("projection", projection)
+		for face, view := range views {
+			shader.SetMat4("view", view)
+			gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_CUBE_MAP_POSITIVE_X+uint32(face), cubemap, level)
+			if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+				return fmt.Errorf("environment framebuffer is not complete, status 0x%X", status)
+			}
+			gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+			DrawCube()
+		}
+		return nil
+	}
+
+	// The equirectangular image into the cubemap, then its mips to sample it without bright dots when prefiltering
+	shaders["equirectangular"].Use()
+	shaders["equirectangular"].SetInt("equirectangularMap", 0)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, hdrTexture)
+	if err := renderFaces(shaders["equirectangular"], e.Cubemap, EnvironmentCubemapSize, 0); err != nil {
+		e.Delete()
+		return nil, err
+	}
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, e.Cubemap)
+	gl.GenerateMipmap(gl.TEXTURE_CUBE_MAP)
+
+	// Diffuse irradiance
+	shaders["irradiance"].Use()
+	shaders["irradiance"].SetInt("environmentMap", 0)
+	if err := renderFaces(shaders["irradiance"], e.Irradiance, IrradianceSize, 0); err != nil {
+		e.Delete()
+		return nil, err
+	}
+
+	// Specular, each mip is blurred for a higher roughness
+	shaders["prefilter"].Use()
+	shaders["prefilter"].SetInt("environmentMap", 0)
+	shaders["prefilter"].SetFloat("resolution", EnvironmentCubemapSize)
+	for mip := int32(0); mip < PrefilterMipLevels; mip++ {
+		shaders["prefilter"].SetFloat("roughness", float32(mip)/float32(PrefilterMipLevels-1))
+		if err := renderFaces(shaders["prefilter"], e.Prefilter, PrefilterSize>>mip, mip); err != nil {
+			e.Delete()
+			return nil, err
+		}
+	}
+
+	// BRDF integration, it doesn't depend on the environment
+	gl.RenderbufferStorage(gl.RENDERBUFFER, gl.DEPTH_COMPONENT24, BRDFLUTSize, BRDFLUTSize)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, e.BRDFLUT, 0)
+	gl.Viewport(0, 0, BRDFLUTSize, BRDFLUTSize)
+	shaders["brdf"].Use()
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	DrawQuad()
+
+	return e, nil
+}
+
+// It creates the empty textures of the maps with their formats and filters
+func newEnvironmentTextures() *Environment {
+	e := &Environment{
+		Cubemap:    NewCubemap(EnvironmentCubemapSize, gl.RGB16F, gl.RGB, gl.FLOAT),
+		Irradiance: NewCubemap(IrradianceSize, gl.RGB16F, gl.RGB, gl.FLOAT),
+		Prefilter:  NewCubemap(PrefilterSize, gl.RGB16F, gl.RGB, gl.FLOAT),
+	}
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, e.Cubemap)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MIN_FILTER, gl.LINEAR_MIPMAP_LINEAR)
+	// The prefiltered map needs its mips allocated before rendering into them
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, e.Prefilter)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MIN_FILTER, gl.LINEAR_MIPMAP_LINEAR)
+	gl.GenerateMipmap(gl.TEXTURE_CUBE_MAP)
+
+	gl.GenTextures(1, &e.BRDFLUT)
+	gl.BindTexture(gl.TEXTURE_2D, e.BRDFLUT)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RG16F, BRDFLUTSize, BRDFLUTSize, 0, gl.RG, gl.FLOAT, nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+
+	return e
+}
+
+// The cache stores the magic, the key of how the maps were computed and every level of every map as half floats,
+// in the same order they are created
+const environmentCacheMagic = "GIBL"
+
+// It hashes the sizes of the maps and the sources of the shaders that compute them,
+// so the cache is computed again when any of them changes
+func environmentCacheKey(shaderDir string) (uint64, error) {
+	h := fnv.New64a()
+	binary.Write(h, binary.LittleEndian, []int32{EnvironmentCubemapSize, IrradianceSize, PrefilterSize, PrefilterMipLevels, BRDFLUTSize})
+	// The map is sorted so the files are hashed in the same order every time
+	for _, name := range slices.Sorted(maps.Keys(environmentShaders)) {
+		for _, file := range environmentShaders[name] {
+			source, err := os.ReadFile(filepath.Join(shaderDir, file))
+			if err != nil {
+				return 0, fmt.Errorf("failed to read the environment shaders: %w", err)
+			}
+			h.Write(source)
+		}
+	}
+	return h.Sum64(), nil
+}
+
+// Each level of the cache: the texture, the target of the image and its size and components
+type cacheLevel struct {
+	texture    uint32
+	bindTarget uint32
+	target     uint32
+	level      int32
+	size       int32
+	format     uint32
+	components int
+}
+
+func (e *Environment) cacheLevels() []cacheLevel {
+	var levels []cacheLevel
+	for face := uint32(0); face < 6; face++ {
+		levels = append(levels, cacheLevel{e.Cubemap, gl.TEXTURE_CUBE_MAP, gl.TEXTURE_CUBE_MAP_POSITIVE_X + face, 0, EnvironmentCubemapSize, gl.RGB, 3})
+		levels = append(levels, cacheLevel{e.Irradiance, gl.TEXTURE_CUBE_MAP, gl.TEXTURE_CUBE_MAP_POSITIVE_X + face, 0, IrradianceSize, gl.RGB, 3})
+		for mip := int32(0); mip < PrefilterMipLevels; mip++ {
+			levels = append(levels, cacheLevel{e.Prefilter, gl.TEXTURE_CUBE_MAP, gl.TEXTURE_CUBE_MAP_POSITIVE_X + face, mip, PrefilterSize >> mip, gl.RGB, 3})
+		}
+	}
+	return append(levels, cacheLevel{e.BRDFLUT, gl.TEXTURE_2D, gl.TEXTURE_2D, 0, BRDFLUTSize, gl.RG, 2})
+}
+
+func (e *Environment) saveCache(path string, key uint64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	w := bufio.NewWriter(file)
+
+	w.WriteString(environmentCacheMagic)
+	binary.Write(w, binary.LittleEndian, key)
+	gl.PixelStorei(gl.PACK_ALIGNMENT, 1)
+	for _, l := range e.cacheLevels() {
+		data := make([]uint16, int(l.size)*int(l.size)*l.components)
+		gl.BindTexture(l.bindTarget, l.texture)
+		gl.GetTexImage(l.target, l.level, l.format, gl.HALF_FLOAT, gl.Ptr(data))
+		if err := binary.Write(w, binary.LittleEndian, data); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// It loads the maps from the cache, the cache is only valid if it is newer than the .hdr image and it has the same key
+func loadEnvironmentCache(path, hdrPath string, key uint64) (*Environment, error) {
+	cacheInfo, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if hdrInfo, err := os.Stat(hdrPath); err == nil && hdrInfo.ModTime().After(cacheInfo.ModTime()) {
+		return nil, fmt.Errorf("%w: it is older than %s", ErrInvalidEnvironmentCache, hdrPath)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	r := bufio.NewReader(file)
+
+	magic := make([]byte, len(environmentCacheMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != environmentCacheMagic {
+		return nil, ErrInvalidEnvironmentCache
+	}
+	var cachedKey uint64
+	if err := binary.Read(r, binary.LittleEndian, &cachedKey); err != nil || cachedKey != key {
+		return nil, fmt.Errorf("%w: it was computed with other sizes or shaders", ErrInvalidEnvironmentCache)
+	}
+	e := newEnvironmentTextures()
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
+	for _, l := range e.cacheLevels() {
+		data := make([]uint16, int(l.size)*int(l.size)*l.components)
+		if err := binary.Read(r, binary.LittleEndian, data); err != nil {
+			e.Delete()
+			return nil, fmt.Errorf("%w: %w", ErrInvalidEnvironmentCache, err)
+		}
+		internalFormat := int32(gl.RGB16F)
+		if l.components == 2 {
+			internalFormat = gl.RG16F
+		}
+		gl.BindTexture(l.bindTarget, l.texture)
+		gl.TexImage2D(l.target, l.level, internalFormat, l.size, l.size, 0, l.format, gl.HALF_FLOAT, gl.Ptr(data))
+	}
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, e.Cubemap)
+	gl.GenerateMipmap(gl.TEXTURE_CUBE_MAP)
+
+	return e, nil
+}
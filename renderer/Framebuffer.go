@@ -0,0 +1,258 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+var (
+	ErrFramebufferIncomplete = errors.New("framebuffer is not complete")
+	ErrFramebufferSize       = errors.New("framebuffer size must be positive")
+)
+
+// Kind of the depth and stencil buffer of a framebuffer
+type DepthAttachment int
+
+const (
+	// Without depth, for the passes that only draw full screen quads
+	DepthNone DepthAttachment = iota
+	// Depth and stencil renderbuffer, faster but it can't be sampled
+	DepthRenderbuffer
+	// Depth and stencil texture, so later passes can read the depth
+	DepthTexture
+)
+
+// Format of a color attachment, like gl.RGBA8 with gl.RGBA and gl.UNSIGNED_BYTE or gl.RGBA16F with gl.RGBA and gl.FLOAT for HDR
+type ColorAttachment struct {
+	InternalFormat int32
+	Format         uint32
+	Type           uint32
+}
+
+// Common color attachments
+var (
+	ColorRGBA8   = ColorAttachment{gl.RGBA8, gl.RGBA, gl.UNSIGNED_BYTE}
+	ColorRGBA16F = ColorAttachment{gl.RGBA16F, gl.RGBA, gl.FLOAT}
+)
+
+type FramebufferConfig struct {
+	Width, Height int32
+	// One texture per attachment, in the order of the outputs of the fragment shader
+	Colors []ColorAttachment
+	// More than 1 makes it multisampled, then the attachments are renderbuffers and it has to be resolved to be sampled
+	Samples int32
+	Depth   DepthAttachment
+	// The depth is a 32 bit float instead of a 24 bit integer, the reversed depth of the camera needs it.
+	// The framebuffers that the depth is resolved into must have it too
+	FloatDepth bool
+}
+
+// Framebuffer object with its attachments, to render somewhere else than the window
+type Framebuffer struct {
+	FBO uint32
+	// Textures of the color attachments, or renderbuffers if it is multisampled
+	ColorAttachments []uint32
+	// Texture with the depth and stencil if the config asked for DepthTexture, 0 otherwise
+	DepthTexture uint32
+	depthRBO     uint32
+
+	Width, Height int32
+	config        FramebufferConfig
+}
+
+func NewFramebuffer(config FramebufferConfig) (*Framebuffer, error) {
+	f := &Framebuffer{config: config}
+	gl.GenFramebuffers(1, &f.FBO)
+	if err := f.Resize(config.Width, config.Height); err != nil {
+		f.Delete()
+		return nil, err
+	}
+	return f, nil
+}
+
+// It recreates the attachments with the new size, the content is lost. It does nothing if the size is the same
+func (f *Framebuffer) Resize(width, height int32) error {
+	if width <= 0 || height <= 0 {
+		// Minimized windows have a size of 0, we keep the attachments until they have a size again
+		return fmt.Errorf("%w: %dx%d", ErrFramebufferSize, width, height)
+	}
+	if width == f.Width && height == f.Height && len(f.ColorAttachments) == len(f.config.Colors) {
+		return nil
+	}
+	f.deleteAttachments()
+	f.Width, f.Height = width, height
+	multisampled := f.config.Samples > 1
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.FBO)
+	defer gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+
+	drawBuffers := make([]uint32, len(f.config.Colors))
+	for i, color := range f.config.Colors {
+		attachment := gl.COLOR_ATTACHMENT0 + uint32(i)
+		var id uint32
+		if multisampled {
+			gl.GenRenderbuffers(1, &id)
+			gl.BindRenderbuffer(gl.RENDERBUFFER, id)
+			gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, f.config.Samples, uint32(color.InternalFormat), width, height)
+			gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, attachment, gl.RENDERBUFFER, id)
+		} else {
+			gl.GenTextures(1, &id)
+			gl.BindTexture(gl.TEXTURE_2D, id)
+			gl.TexImage2D(gl.TEXTURE_2D, 0, color.InternalFormat, width, height, 0, color.Format, color.Type, nil)
+			gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+			gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+			// The post processing samples around the pixels, so the edges must not wrap
+			gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+			gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+			gl.FramebufferTexture2D(gl.FRAMEBUFFER, attachment, gl.TEXTURE_2D, id, 0)
+		}
+		f.ColorAttachments = append(f.ColorAttachments, id)
+		drawBuffers[i] = attachment
+	}
+	if len(drawBuffers) > 0 {
+		gl.DrawBuffers(int32(len(drawBuffers)), &drawBuffers[0])
+		gl.ReadBuffer(gl.COLOR_ATTACHMENT0)
+	} else {
+		gl.DrawBuffer(gl.NONE)
+		gl.ReadBuffer(gl.NONE)
+	}
+
+	depthFormat, depthType := uint32(gl.DEPTH24_STENCIL8), uint32(gl.UNSIGNED_INT_24_8)
+	if f.config.FloatDepth {
+		depthFormat, depthType = gl.DEPTH32F_STENCIL8, gl.FLOAT_32_UNSIGNED_INT_24_8_REV
+	}
+	switch f.config.Depth {
+	case DepthRenderbuffer:
+		gl.GenRenderbuffers(1, &f.depthRBO)
+		gl.BindRenderbuffer(gl.RENDERBUFFER, f.depthRBO)
+		if multisampled {
+			gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, f.config.Samples, depthFormat, width, height)
+		} else {
+			gl.RenderbufferStorage(gl.RENDERBUFFER, depthFormat, width, height)
+		}
+		gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_STENCIL_ATTACHMENT, gl.RENDERBUFFER, f.depthRBO)
+	case DepthTexture:
+		if multisampled {
+			return fmt.Errorf("%w: a multisampled framebuffer can't have a depth texture, use a renderbuffer and resolve it", ErrFramebufferIncomplete)
+		}
+		gl.GenTextures(1, &f.DepthTexture)
+		gl.BindTexture(gl.TEXTURE_2D, f.DepthTexture)
+		gl.TexImage2D(gl.TEXTURE_2D, 0, int32(depthFormat), width, height, 0, gl.DEPTH_STENCIL, depthType, nil)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+		gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.DEPTH_STENCIL_ATTACHMENT, gl.TEXTURE_2D, f.DepthTexture, 0)
+	}
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+
+	if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		return fmt.Errorf("%w: %s (0x%X), %dx%d with %d color attachments and %d samples",
+			ErrFramebufferIncomplete, framebufferStatusName(status), status, width, height, len(f.config.Colors), f.config.Samples)
+	}
+	return nil
+}
+
+// It binds the framebuffer and sets the viewport to its size
+func (f *Framebuffer) Bind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.FBO)
+	gl.Viewport(0, 0, f.Width, f.Height)
+}
+
+// It binds the window as the framebuffer to draw, with the viewport of the given size
+func BindDefaultFramebuffer(width, height int32) {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	gl.Viewport(0, 0, width, height)
+}
+
+// It copies the buffers in mask, like gl.COLOR_BUFFER_BIT, into dst, scaling them to its size. The color is copied from the first attachment.
+// With a nil dst it copies to the window, which must be of the given size. Blitting from a multisampled framebuffer resolves it,
+// but then both must have the same size and the filter must be gl.NEAREST for the depth
+func (f *Framebuffer) BlitTo(dst *Framebuffer, width, height int32, mask uint32, filter uint32) {
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, f.FBO)
+	gl.ReadBuffer(gl.COLOR_ATTACHMENT0)
+	if dst != nil {
+		gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, dst.FBO)
+		width, height = dst.Width, dst.Height
+	} else {
+		gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, 0)
+	}
+	gl.BlitFramebuffer(0, 0, f.Width, f.Height, 0, 0, width, height, mask, filter)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+}
+
+// It resolves every color attachment of the multisampled framebuffer into the attachment with the same index of dst,
+// and the depth if both have it. Then the textures of dst can be sampled
+func (f *Framebuffer) Resolve(dst *Framebuffer) {
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, f.FBO)
+	gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, dst.FBO)
+	for i := 0; i < len(f.ColorAttachments) && i < len(dst.ColorAttachments); i++ {
+		attachment := gl.COLOR_ATTACHMENT0 + uint32(i)
+		gl.ReadBuffer(attachment)
+		gl.DrawBuffer(attachment)
+		gl.BlitFramebuffer(0, 0, f.Width, f.Height, 0, 0, dst.Width, dst.Height, gl.COLOR_BUFFER_BIT, gl.NEAREST)
+	}
+	if f.config.Depth != DepthNone && dst.config.Depth != DepthNone {
+		gl.BlitFramebuffer(0, 0, f.Width, f.Height, 0, 0, dst.Width, dst.Height, gl.DEPTH_BUFFER_BIT, gl.NEAREST)
+	}
+
+	// We leave the draw buffers of dst as they were
+	drawBuffers := make([]uint32, len(dst.ColorAttachments))
+	for i := range drawBuffers {
+		drawBuffers[i] = gl.COLOR_ATTACHMENT0 + uint32(i)
+	}
+	if len(drawBuffers) > 0 {
+		gl.DrawBuffers(int32(len(drawBuffers)), &drawBuffers[0])
+	}
+	gl.ReadBuffer(gl.COLOR_ATTACHMENT0)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+}
+
+func (f *Framebuffer) Delete() {
+	f.deleteAttachments()
+	gl.DeleteFramebuffers(1, &f.FBO)
+}
+
+func (f *Framebuffer) deleteAttachments() {
+	if len(f.ColorAttachments) > 0 {
+		if f.config.Samples > 1 {
+			gl.DeleteRenderbuffers(int32(len(f.ColorAttachments)), &f.ColorAttachments[0])
+		} else {
+			gl.DeleteTextures(int32(len(f.ColorAttachments)), &f.ColorAttachments[0])
+		}
+	}
+	f.ColorAttachments = nil
+	if f.DepthTexture != 0 {
+		gl.DeleteTextures(1, &f.DepthTexture)
+		f.DepthTexture = 0
+	}
+	if f.depthRBO != 0 {
+		gl.DeleteRenderbuffers(1, &f.depthRBO)
+		f.depthRBO = 0
+	}
+}
+
+// Name of the status returned by glCheckFramebufferStatus, to know what is wrong
+func framebufferStatusName(status uint32) string {
+	switch status {
+	case gl.FRAMEBUFFER_UNDEFINED:
+		return "the default framebuffer doesn't exist"
+	case gl.FRAMEBUFFER_INCOMPLETE_ATTACHMENT:
+		return "an attachment is incomplete"
+	case gl.FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT:
+		return "it has no attachments"
+	case gl.FRAMEBUFFER_INCOMPLETE_DRAW_BUFFER:
+		return "a draw buffer has no attachment"
+	case gl.FRAMEBUFFER_INCOMPLETE_READ_BUFFER:
+		return "the read buffer has no attachment"
+	case gl.FRAMEBUFFER_UNSUPPORTED:
+		return "the combination of formats is not supported"
+	case gl.FRAMEBUFFER_INCOMPLETE_MULTISAMPLE:
+		return "the attachments have different samples"
+	default:
+		return "unknown status"
+	}
+}
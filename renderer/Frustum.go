@@ -0,0 +1,64 @@
+package renderer
+
+import (
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Planes of the volume that the camera sees, each one is (a, b, c, d) with ax + by + cz + d >= 0 inside and the normal pointing in
+type Frustum struct {
+	Planes [6]glm.Vec4
+}
+
+// Function that extracts the planes from projection × view, the points inside have their clip coordinates between -w and w
+func NewFrustum(viewProjection glm.Mat4) Frustum {
+	row := func(i int) glm.Vec4 {
+		return viewProjection.Row(i)
+	}
+	f := Frustum{Planes: [6]glm.Vec4{
+		row(3).Add(row(0)), // Left
+		row(3).Sub(row(0)), // Right
+		row(3).Add(row(1)), // Bottom
+		row(3).Sub(row(1)), // Top
+		row(3).Add(row(2)), // Near
+		row(3).Sub(row(2)), // Far
+	}}
+	// Normalized so the distances to the planes are real distances, the spheres need them
+	for i, plane := range f.Planes {
+		if length := plane.Vec3().Len(); length > 0 {
+			f.Planes[i] = plane.Mul(1 / length)
+		}
+	}
+	return f
+}
+
+// It tells if the box is at least partly inside. It can say that some boxes near the corners are inside when they aren't,
+// which only means that they are drawn
+func (f Frustum) IntersectsAABB(box AABB) bool {
+	if box.IsEmpty() {
+		return false
+	}
+	for _, plane := range f.Planes {
+		// The corner of the box that is furthest along the normal of the plane, if it is outside the whole box is
+		var p glm.Vec3
+		for i := 0; i < 3; i++ {
+			if plane[i] >= 0 {
+				p[i] = box.Max[i]
+			} else {
+				p[i] = box.Min[i]
+			}
+		}
+		if plane.Vec3().Dot(p)+plane[3] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (f Frustum) IntersectsSphere(sphere Sphere) bool {
+	for _, plane := range f.Planes {
+		if plane.Vec3().Dot(sphere.Center)+plane[3] < -sphere.Radius {
+			return false
+		}
+	}
+	return true
+}
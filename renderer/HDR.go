@@ -0,0 +1,178 @@
+package renderer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+var ErrInvalidHDR = errors.New("invalid Radiance HDR file")
+
+// Biggest HDR image that we load, a side as big as the biggest textures of the GPUs and the pixels of a 16K equirectangular map.
+// The size comes from the header, so a corrupt one would allocate gigabytes before the pixels fail to read
+const (
+	MaxHDRSide   = 16384
+	MaxHDRPixels = 16384 * 8192
+)
+
+// Function that reads a Radiance .hdr (RGBE) image, it returns the pixels as RGB floats from the top row to the bottom one
+func LoadHDR(path string) (int, int, []float32, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, nil, &TextureError{Path: path, Err: err}
+	}
+	defer file.Close()
+
+	width, height, pixels, err := decodeHDR(bufio.NewReader(file))
+	if err != nil {
+		return 0, 0, nil, &TextureError{Path: path, Err: fmt.Errorf("%w: %w", ErrTextureDecode, err)}
+	}
+	return width, height, pixels, nil
+}
+
+func decodeHDR(r *bufio.Reader) (int, int, []float32, error) {
+	// The header is a list of lines that ends with an empty one, we only support the RGBE format
+	magic, err := r.ReadString('\n')
+	if err != nil || !strings.HasPrefix(magic, "#?") {
+		return 0, 0, nil, ErrInvalidHDR
+	}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("%w: unexpected end of the header", ErrInvalidHDR)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if format, ok := strings.CutPrefix(line, "FORMAT="); ok && format != "32-bit_rle_rgbe" {
+			return 0, 0, nil, fmt.Errorf("%w: unsupported format %s", ErrInvalidHDR, format)
+		}
+	}
+	// Then the resolution, we only support the standard orientation
+	var width, height int
+	resolution, err := r.ReadString('\n')
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("%w: missing resolution", ErrInvalidHDR)
+	}
+	if _, err := fmt.Sscanf(resolution, "-Y %d +X %d", &height, &width); err != nil || width <= 0 || height <= 0 {
+		return 0, 0, nil, fmt.Errorf("%w: unsupported resolution %q", ErrInvalidHDR, strings.TrimSpace(resolution))
+	}
+	if width > MaxHDRSide || height > MaxHDRSide || width*height > MaxHDRPixels {
+		return 0, 0, nil, fmt.Errorf("%w: the image of %dx%d is too big", ErrInvalidHDR, width, height)
+	}
+
+	pixels := make([]float32, width*height*3)
+	scanline := make([]byte, width*4)
+	for y := 0; y < height; y++ {
+		if err := readHDRScanline(r, scanline, width); err != nil {
+			return 0, 0, nil, err
+		}
+		for x := 0; x < width; x++ {
+			rgbeToFloats(scanline[x*4:x*4+4], pixels[(y*width+x)*3:])
+		}
+	}
+	return width, height, pixels, nil
+}
+
+// It reads a scanline into RGBE quadruplets, the scanlines can be flat or run length encoded per channel
+func readHDRScanline(r *bufio.Reader, scanline []byte, width int) error {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHDR, err)
+	}
+	// The new RLE scanlines start with 2, 2 and the width, the rest of the files store the pixels as they are
+	if width < 8 || width > 0x7fff || header[0] != 2 || header[1] != 2 || header[2]&0x80 != 0 {
+		copy(scanline, header)
+		if _, err := io.ReadFull(r, scanline[4:]); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidHDR, err)
+		}
+		return nil
+	}
+	if int(header[2])<<8|int(header[3]) != width {
+		return fmt.Errorf("%w: wrong scanline width", ErrInvalidHDR)
+	}
+
+	// Each channel comes separated, as runs of the same value or as literal values
+	for channel := 0; channel < 4; channel++ {
+		for x := 0; x < width; {
+			count, err := r.ReadByte()
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidHDR, err)
+			}
+			if count > 128 {
+				n := int(count - 128)
+				value, err := r.ReadByte()
+				if err != nil || x+n > width {
+					return fmt.Errorf("%w: bad run", ErrInvalidHDR)
+				}
+				for i := 0; i < n; i++ {
+					scanline[(x+i)*4+channel] = value
+				}
+				x += n
+			} else {
+				n := int(count)
+				if n == 0 || x+n > width {
+					return fmt.Errorf("%w: bad literal run", ErrInvalidHDR)
+				}
+				for i := 0; i < n; i++ {
+					value, err := r.ReadByte()
+					if err != nil {
+						return fmt.Errorf("%w: %w", ErrInvalidHDR, err)
+					}
+					scanline[(x+i)*4+channel] = value
+				}
+				x += n
+			}
+		}
+	}
+	return nil
+}
+
+// The three channels share the exponent stored in the fourth byte
+func rgbeToFloats(rgbe []byte, rgb []float32) {
+	if rgbe[3] == 0 {
+		rgb[0], rgb[1], rgb[2] = 0, 0, 0
+		return
+	}
+	f := float32(math.Ldexp(1., int(rgbe[3])-(128+8)))
+	rgb[0] = float32(rgbe[0]) * f
+	rgb[1] = float32(rgbe[1]) * f
+	rgb[2] = float32(rgbe[2]) * f
+}
+
+// Function that loads a .hdr image into a float texture, flipped like TextureFromFile so the first row is the bottom one
+func HDRTextureFromFile(path string) (uint32, error) {
+	width, height, pixels, err := LoadHDR(path)
+	if err != nil {
+		return 0, err
+	}
+	rowStride := width * 3
+	tmpRow := make([]float32, rowStride)
+	for i := 0; i < height/2; i++ {
+		topRow := pixels[i*rowStride : (i+1)*rowStride]
+		bottomRow := pixels[(height-1-i)*rowStride : (height-i)*rowStride]
+		copy(tmpRow, topRow)
+		copy(topRow, bottomRow)
+		copy(bottomRow, tmpRow)
+	}
+
+	var textureID uint32
+	gl.GenTextures(1, &textureID)
+	gl.BindTexture(gl.TEXTURE_2D, textureID)
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGB16F, int32(width), int32(height), 0, gl.RGB, gl.FLOAT, gl.Ptr(pixels))
+
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+
+	return textureID, nil
+}
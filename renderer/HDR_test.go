@@ -0,0 +1,87 @@
+package renderer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// It builds an HDR file with the header of the resolution line and the scanlines as they are
+func hdrFile(format, resolution string, scanlines ...[]byte) []byte {
+	file := []byte("#?RADIANCE\nFORMAT=" + format + "\n\n" + resolution + "\n")
+	for _, scanline := range scanlines {
+		file = append(file, scanline...)
+	}
+	return file
+}
+
+// RLE scanline of 8 pixels, each channel is a run of 3 values and a literal of 5
+func rleScanline(runs [4]byte, literals [4][5]byte) []byte {
+	scanline := []byte{2, 2, 0, 8}
+	for channel := 0; channel < 4; channel++ {
+		scanline = append(scanline, 128+3, runs[channel], 5)
+		scanline = append(scanline, literals[channel][:]...)
+	}
+	return scanline
+}
+
+func TestDecodeHDR(t *testing.T) {
+	// 129 as the exponent gives 2^-7, so the channels are their value divided by 128
+	flat := []byte{128, 64, 0, 129, 0, 0, 0, 0}
+	rle := rleScanline([4]byte{128, 64, 32, 129}, [4][5]byte{
+		{0, 16, 32, 64, 128},
+		{0, 0, 0, 0, 0},
+		{128, 128, 128, 128, 128},
+		{129, 129, 129, 129, 0},
+	})
+	overflow := []byte{2, 2, 0, 8, 128 + 9, 1}
+
+	tests := []struct {
+		name          string
+		file          []byte
+		width, height int
+		pixels        []float32
+		err           bool
+	}{
+		{"flat scanline", hdrFile("32-bit_rle_rgbe", "-Y 1 +X 2", flat), 2, 1, []float32{1, 0.5, 0, 0, 0, 0}, false},
+		{"rle scanline", hdrFile("32-bit_rle_rgbe", "-Y 1 +X 8", rle), 8, 1, []float32{
+			1, 0.5, 0.25, 1, 0.5, 0.25, 1, 0.5, 0.25,
+			0, 0, 1, 0.125, 0, 1, 0.25, 0, 1, 0.5, 0, 1, 0, 0, 0,
+		}, false},
+		{"bad format", hdrFile("32-bit_rle_xyze", "-Y 1 +X 2", flat), 0, 0, nil, true},
+		{"unsupported resolution", hdrFile("32-bit_rle_rgbe", "+Y 1 +X 2", flat), 0, 0, nil, true},
+		{"too big", hdrFile("32-bit_rle_rgbe", "-Y 100000 +X 100000", flat), 0, 0, nil, true},
+		{"truncated flat scanline", hdrFile("32-bit_rle_rgbe", "-Y 1 +X 2", flat[:6]), 0, 0, nil, true},
+		{"truncated rle scanline", hdrFile("32-bit_rle_rgbe", "-Y 1 +X 8", rle[:20]), 0, 0, nil, true},
+		{"missing scanline", hdrFile("32-bit_rle_rgbe", "-Y 2 +X 2", flat), 0, 0, nil, true},
+		{"run over the width", hdrFile("32-bit_rle_rgbe", "-Y 1 +X 8", overflow), 0, 0, nil, true},
+		{"no magic", []byte("RADIANCE\n\n-Y 1 +X 2\n"), 0, 0, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			width, height, pixels, err := decodeHDR(bufio.NewReader(bytes.NewReader(test.file)))
+			if test.err {
+				if !errors.Is(err, ErrInvalidHDR) {
+					t.Errorf("got %v, want %v", err, ErrInvalidHDR)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if width != test.width || height != test.height {
+				t.Errorf("got %dx%d, want %dx%d", width, height, test.width, test.height)
+			}
+			if len(pixels) != len(test.pixels) {
+				t.Fatalf("got %d floats, want %d", len(pixels), len(test.pixels))
+			}
+			for i := range pixels {
+				if pixels[i] != test.pixels[i] {
+					t.Errorf("got %v, want %v", pixels, test.pixels)
+					break
+				}
+			}
+		})
+	}
+}
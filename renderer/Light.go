@@ -0,0 +1,371 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Maximum number of lights of each kind, they must match the sizes of the arrays in the lighting fragment shader
+const (
+	MaxDirectionalLights = 4
+	MaxPointLights       = 8
+	MaxSpotLights        = 4
+)
+
+var ErrTooManyLights = errors.New("too many lights of the same kind")
+
+// Default attenuation terms, they give a range of about 50 units
+const (
+	AttenuationConstant  = float32(1.)
+	AttenuationLinear    = float32(0.09)
+	AttenuationQuadratic = float32(0.032)
+)
+
+// Intensity of each component of the Blinn-Phong model
+type LightColor struct {
+	Ambient  glm.Vec3
+	Diffuse  glm.Vec3
+	Specular glm.Vec3
+}
+
+// It builds the components from a single color, with a dim ambient term and a white specular one
+func NewLightColor(color glm.Vec3) LightColor {
+	return LightColor{
+		Ambient:  color.Mul(0.05),
+		Diffuse:  color,
+		Specular: glm.Vec3{1, 1, 1},
+	}
+}
+
+// Light is implemented by DirectionalLight, PointLight and SpotLight
+type Light interface {
+	// It uploads the light to the element index of its array in the shader
+	apply(shader *Shader, index int)
+}
+
+// Light that comes from infinitely far away, like the sun, so it only has a direction
+type DirectionalLight struct {
+	Direction glm.Vec3
+	Color     LightColor
+
+	// If it isn't nil the light casts shadows into it
+	Shadow *ShadowMap
+}
+
+func NewDirectionalLight(direction, color glm.Vec3) *DirectionalLight {
+	return &DirectionalLight{
+		Direction: direction.Normalize(),
+		Color:     NewLightColor(color),
+	}
+}
+
+// It creates the shadow map of the light so it casts shadows
+func (l *DirectionalLight) EnableShadows(size int32) error {
+	shadow, err := NewShadowMap(size)
+	if err != nil {
+		return err
+	}
+	l.Shadow = shadow
+	return nil
+}
+
+// It returns the orthographic camera of the shadow map, that covers the scene bounds given as a bounding sphere
+func (l *DirectionalLight) ShadowCamera(center glm.Vec3, radius float32) *Camera {
+	// We place the light outside of the bounds looking at their center
+	direction := l.Direction.Normalize()
+	return &Camera{
+		Position:   center.Sub(direction.Mul(radius * 2)),
+		Front:      direction,
+		Up:         lightUp(direction),
+		Projection: Orthographic,
+		OrthoSize:  radius,
+		Aspect:     1,
+		Near:       radius * 0.01,
+		Far:        radius * 3,
+	}
+}
+
+// It returns the matrix of the shadow camera, from the world to the clip space of the light
+func (l *DirectionalLight) LightSpaceMatrix(center glm.Vec3, radius float32) glm.Mat4 {
+	return l.ShadowCamera(center, radius).ViewProjection()
+}
+
+func (l *DirectionalLight) apply(shader *Shader, index int) {
+	name := fmt.Sprintf("dirLights[%d]", index)
+	shader.SetVec3(name+".direction", l.Direction)
+	applyColor(shader, name, l.Color)
+}
+
+// Light that shines in every direction from a position, fading with the distance
+type PointLight struct {
+	Position glm.Vec3
+	Color    LightColor
+
+	Constant  float32
+	Linear    float32
+	Quadratic float32
+
+	// If it isn't nil the light casts shadows in every direction into it
+	Shadow *PointShadowMap
+}
+
+func NewPointLight(position, color glm.Vec3) *PointLight {
+	return &PointLight{
+		Position:  position,
+		Color:     NewLightColor(color),
+		Constant:  AttenuationConstant,
+		Linear:    AttenuationLinear,
+		Quadratic: AttenuationQuadratic,
+	}
+}
+
+// It creates the depth cubemap of the light so it casts shadows, only the geometry closer than farPlane casts them
+func (l *PointLight) EnableShadows(size int32, farPlane float32) error {
+	shadow, err := NewPointShadowMap(size, farPlane)
+	if err != nil {
+		return err
+	}
+	l.Shadow = shadow
+	return nil
+}
+
+func (l *PointLight) apply(shader *Shader, index int) {
+	name := fmt.Sprintf("pointLights[%d]", index)
+	shader.SetVec3(name+".position", l.Position)
+	applyColor(shader, name, l.Color)
+	applyAttenuation(shader, name, l.Constant, l.Linear, l.Quadratic)
+}
+
+// Light that shines from a position in a cone, like a flashlight.
+// Between the inner and the outer cone the intensity fades smoothly to 0
+type SpotLight struct {
+	Position  glm.Vec3
+	Direction glm.Vec3
+	Color     LightColor
+
+	Constant  float32
+	Linear    float32
+	Quadratic float32
+
+	// Angles of the cones in degrees
+	CutOff      float32
+	OuterCutOff float32
+
+	// If it isn't nil the light casts shadows into it
+	Shadow *ShadowMap
+}
+
+func NewSpotLight(position, direction, color glm.Vec3, cutOff, outerCutOff float32) *SpotLight {
+	return &SpotLight{
+		Position:    position,
+		Direction:   direction.Normalize(),
+		Color:       NewLightColor(color),
+		Constant:    AttenuationConstant,
+		Linear:      AttenuationLinear,
+		Quadratic:   AttenuationQuadratic,
+		CutOff:      cutOff,
+		OuterCutOff: outerCutOff,
+	}
+}
+
+// It creates the shadow map of the light so it casts shadows
+func (l *SpotLight) EnableShadows(size int32) error {
+	shadow, err := NewShadowMap(size)
+	if err != nil {
+		return err
+	}
+	l.Shadow = shadow
+	return nil
+}
+
+// It returns the perspective camera of the shadow map, that covers the outer cone of the light until the end of the scene bounds
+func (l *SpotLight) ShadowCamera(center glm.Vec3, radius float32) *Camera {
+	direction := l.Direction.Normalize()
+	return &Camera{
+		Position:   l.Position,
+		Front:      direction,
+		Up:         lightUp(direction),
+		Projection: Perspective,
+		Zoom:       l.OuterCutOff * 2,
+		Aspect:     1,
+		Near:       0.1,
+		Far:        l.Position.Sub(center).Len() + radius,
+	}
+}
+
+// It returns the matrix of the shadow camera, from the world to the clip space of the light
+func (l *SpotLight) LightSpaceMatrix(center glm.Vec3, radius float32) glm.Mat4 {
+	return l.ShadowCamera(center, radius).ViewProjection()
+}
+
+func (l *SpotLight) apply(shader *Shader, index int) {
+	name := fmt.Sprintf("spotLights[%d]", index)
+	shader.SetVec3(name+".position", l.Position)
+	shader.SetVec3(name+".direction", l.Direction)
+	applyColor(shader, name, l.Color)
+	applyAttenuation(shader, name, l.Constant, l.Linear, l.Quadratic)
+	// We pass the cosines so the shader can compare them with a dot product
+	shader.SetFloat(name+".cutOff", float32(math.Cos(float64(glm.DegToRad(l.CutOff)))))
+	shader.SetFloat(name+".outerCutOff", float32(math.Cos(float64(glm.DegToRad(l.OuterCutOff)))))
+}
+
+func applyColor(shader *Shader, name string, color LightColor) {
+	shader.SetVec3(name+".ambient", color.Ambient)
+	shader.SetVec3(name+".diffuse", color.Diffuse)
+	shader.SetVec3(name+".specular", color.Specular)
+}
+
+func applyAttenuation(shader *Shader, name string, constant, linear, quadratic float32) {
+	shader.SetFloat(name+".constant", constant)
+	shader.SetFloat(name+".linear", linear)
+	shader.SetFloat(name+".quadratic", quadratic)
+}
+
+// Group of lights that are uploaded together to the lighting shader every frame
+type LightSet struct {
+	Directional []*DirectionalLight
+	Point       []*PointLight
+	Spot        []*SpotLight
+}
+
+// It adds the light to the set, failing if the shader can't hold more lights of its kind
+func (ls *LightSet) Add(light Light) error {
+	switch l := light.(type) {
+	case *DirectionalLight:
+		if len(ls.Directional) >= MaxDirectionalLights {
+			return fmt.Errorf("%w: the maximum of directional lights is %d", ErrTooManyLights, MaxDirectionalLights)
+		}
+		ls.Directional = append(ls.Directional, l)
+	case *PointLight:
+		if len(ls.Point) >= MaxPointLights {
+			return fmt.Errorf("%w: the maximum of point lights is %d", ErrTooManyLights, MaxPointLights)
+		}
+		ls.Point = append(ls.Point, l)
+	case *SpotLight:
+		if len(ls.Spot) >= MaxSpotLights {
+			return fmt.Errorf("%w: the maximum of spot lights is %d", ErrTooManyLights, MaxSpotLights)
+		}
+		ls.Spot = append(ls.Spot, l)
+	}
+	return nil
+}
+
+// It renders the depth of the models that cast shadows into the shadow map of every light that has one.
+// The scene bounds are given as a bounding sphere and are used to fit the light projections
+func (ls *LightSet) RenderShadows(depthShader *Shader, models []*Model, center glm.Vec3, radius float32) {
+	// We save the viewport to restore it after rendering into the shadow maps
+	var viewport [4]int32
+	gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+
+	depthShader.Use()
+	render := func(shadow *ShadowMap, lightSpace glm.Mat4) {
+		shadow.LightSpace = lightSpace
+		shadow.Bind()
+		depthShader.SetMat4("lightSpaceMatrix", lightSpace)
+		for _, model := range models {
+			model.DrawDepth(*depthShader)
+		}
+	}
+	for _, light := range ls.Directional {
+		if light.Shadow != nil {
+			render(light.Shadow, light.LightSpaceMatrix(center, radius))
+		}
+	}
+	for _, light := range ls.Spot {
+		if light.Shadow != nil {
+			render(light.Shadow, light.LightSpaceMatrix(center, radius))
+		}
+	}
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	gl.Viewport(viewport[0], viewport[1], viewport[2], viewport[3])
+}
+
+// It renders the distance to the light of the models that cast shadows into the six faces of every point light with shadows
+func (ls *LightSet) RenderPointShadows(pointDepthShader *Shader, models []*Model) {
+	var viewport [4]int32
+	gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+
+	pointDepthShader.Use()
+	for _, light := range ls.Point {
+		if light.Shadow == nil {
+			continue
+		}
+		pointDepthShader.SetVec3("lightPos", light.Position)
+		pointDepthShader.SetFloat("farPlane", light.Shadow.FarPlane)
+		for face, matrix := range light.Shadow.FaceMatrices(light.Position) {
+			light.Shadow.BindFace(uint32(face))
+			pointDepthShader.SetMat4("lightSpaceMatrix", matrix)
+			for _, model := range models {
+				model.DrawDepth(*pointDepthShader)
+			}
+		}
+	}
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	gl.Viewport(viewport[0], viewport[1], viewport[2], viewport[3])
+}
+
+// It uploads all the lights and the camera position, which is needed for the specular highlights.
+// The shadow maps are bound to the texture units from ShadowTextureUnit, followed by the point shadow maps. The shader must be in use
+func (ls *LightSet) Apply(shader *Shader, camera *Camera) {
+	shader.SetVec3("viewPos", camera.Position)
+
+	shadows := 0
+	shader.SetInt("numDirLights", min(len(ls.Directional), MaxDirectionalLights))
+	for i := 0; i < len(ls.Directional) && i < MaxDirectionalLights; i++ {
+		ls.Directional[i].apply(shader, i)
+		applyShadow(shader, fmt.Sprintf("dirLights[%d]", i), ls.Directional[i].Shadow, &shadows)
+	}
+	shader.SetInt("numPointLights", min(len(ls.Point), MaxPointLights))
+	pointShadows := 0
+	for i := 0; i < len(ls.Point) && i < MaxPointLights; i++ {
+		ls.Point[i].apply(shader, i)
+		applyPointShadow(shader, fmt.Sprintf("pointLights[%d]", i), ls.Point[i].Shadow, &pointShadows)
+	}
+	shader.SetInt("numSpotLights", min(len(ls.Spot), MaxSpotLights))
+	for i := 0; i < len(ls.Spot) && i < MaxSpotLights; i++ {
+		ls.Spot[i].apply(shader, i)
+		applyShadow(shader, fmt.Sprintf("spotLights[%d]", i), ls.Spot[i].Shadow, &shadows)
+	}
+	for i := 0; i < MaxShadowMaps; i++ {
+		shader.SetInt(fmt.Sprintf("shadowMaps[%d]", i), ShadowTextureUnit+i)
+	}
+	for i := 0; i < MaxPointShadowMaps; i++ {
+		shader.SetInt(fmt.Sprintf("pointShadowMaps[%d]", i), ShadowTextureUnit+MaxShadowMaps+i)
+	}
+	gl.ActiveTexture(gl.TEXTURE0)
+}
+
+// It binds the shadow map of the light to the next free unit and tells the light which one it is, or -1 if it has no shadows
+func applyShadow(shader *Shader, name string, shadow *ShadowMap, shadows *int) {
+	if shadow == nil || *shadows >= MaxShadowMaps {
+		shader.SetInt(name+".shadowIndex", -1)
+		return
+	}
+	index := *shadows
+	gl.ActiveTexture(gl.TEXTURE0 + ShadowTextureUnit + uint32(index))
+	gl.BindTexture(gl.TEXTURE_2D, shadow.DepthTexture)
+	shader.SetMat4(fmt.Sprintf("lightSpaceMatrices[%d]", index), shadow.LightSpace)
+	shader.SetInt(name+".shadowIndex", index)
+	*shadows++
+}
+
+// Like applyShadow but for the depth cubemaps of the point lights
+func applyPointShadow(shader *Shader, name string, shadow *PointShadowMap, shadows *int) {
+	if shadow == nil || *shadows >= MaxPointShadowMaps {
+		shader.SetInt(name+".shadowIndex", -1)
+		return
+	}
+	index := *shadows
+	gl.ActiveTexture(gl.TEXTURE0 + ShadowTextureUnit + MaxShadowMaps + uint32(index))
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, shadow.DepthCubemap)
+	shader.SetInt(name+".shadowIndex", index)
+	shader.SetFloat(name+".farPlane", shadow.FarPlane)
+	shader.SetFloat(name+".shadowBias", shadow.Bias)
+	*shadows++
+}
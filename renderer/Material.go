@@ -0,0 +1,325 @@
+package renderer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/bloeys/assimp-go/asig"
+	"github.com/go-gl/gl/v3.3-core/gl"
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// How the fragments of a material are combined with what is already drawn
+type BlendMode int
+
+const (
+	// It replaces what is behind, the alpha is ignored
+	BlendOpaque BlendMode = iota
+	// Opaque, but the fragments with less alpha than the cutoff are discarded, for foliage and fences
+	BlendAlphaTest
+	// It is mixed with what is behind by its alpha, for glass. It must be drawn back to front after the opaque objects
+	BlendAlphaBlend
+	// It is added to what is behind, for fire and glows. It doesn't need sorting but it is drawn with the transparent objects
+	BlendAdditive
+)
+
+// Default alpha under which the alpha tested fragments are discarded
+const DefaultAlphaCutoff = 0.5
+
+// Default depth of the surfaces with parallax occlusion mapping
+const DefaultHeightScale = float32(0.05)
+
+// If the material has to be drawn in the transparent pass
+func (mode BlendMode) Transparent() bool {
+	return mode == BlendAlphaBlend || mode == BlendAdditive
+}
+
+// Metallic/roughness material for the PBR shader. Each factor multiplies its map, and the meshes without maps use only the factors
+type Material struct {
+	Name string
+
+	BaseColor glm.Vec4
+	Metallic  float32
+	Roughness float32
+	AO        float32
+	Emissive  glm.Vec3
+
+	// How much of the environment map is reflected and seen through the surface, from 0 to 1,
+	// and the index of refraction that bends the refracted view
+	Reflectivity float32
+	Refraction   float32
+	IOR          float32
+
+	BlendMode   BlendMode
+	AlphaCutoff float32
+	// If the material writes to the depth buffer, the transparent ones usually don't so the objects behind them are still drawn
+	DepthWrite bool
+
+	// If the meshes with height maps use parallax occlusion mapping, and how deep their surface looks
+	ParallaxMapping bool
+	HeightScale     float32
+
+	// Texture ids of the maps, 0 if the material doesn't have that map
+	BaseColorMap uint32
+	MetallicMap  uint32
+	RoughnessMap uint32
+	AOMap        uint32
+	EmissiveMap  uint32
+	NormalMap    uint32
+}
+
+// It returns a white dielectric material without maps
+func NewMaterial() *Material {
+	return &Material{
+		BaseColor: glm.Vec4{1, 1, 1, 1},
+		Metallic:  0.,
+		Roughness: 0.5,
+		AO:        1.,
+		IOR:       1.5,
+
+		BlendMode:   BlendOpaque,
+		AlphaCutoff: DefaultAlphaCutoff,
+		DepthWrite:  true,
+
+		HeightScale: DefaultHeightScale,
+	}
+}
+
+// It changes the blend mode and writes the depth only for the modes that are not transparent
+func (mat *Material) SetBlendMode(mode BlendMode) {
+	mat.BlendMode = mode
+	mat.DepthWrite = !mode.Transparent()
+}
+
+// It sets the blending and the depth writes of the material, they stay until ResetBlendState
+func (mat *Material) applyBlendState() {
+	gl.DepthMask(mat.DepthWrite)
+	switch mat.BlendMode {
+	case BlendAlphaBlend:
+		gl.Enable(gl.BLEND)
+		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	case BlendAdditive:
+		gl.Enable(gl.BLEND)
+		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE)
+	default:
+		gl.Disable(gl.BLEND)
+	}
+}
+
+// It goes back to the state of the opaque objects, without blending and writing the depth
+func ResetBlendState() {
+	gl.Disable(gl.BLEND)
+	gl.DepthMask(true)
+}
+
+// It uploads the factors and binds the maps to the first texture units, the shader must be in use
+func (mat *Material) Apply(shader *Shader) {
+	mat.apply(shader, func(unit int, id uint32) {
+		gl.ActiveTexture(gl.TEXTURE0 + uint32(unit))
+		gl.BindTexture(gl.TEXTURE_2D, id)
+	})
+	gl.ActiveTexture(gl.TEXTURE0)
+}
+
+// It sets what the depth passes need to discard the same fragments as the alpha test, the base color map is bound to the first unit
+func (mat *Material) applyDepth(shader *Shader) {
+	alphaTest := mat.BlendMode == BlendAlphaTest
+	shader.SetBool("alphaTest", alphaTest)
+	if !alphaTest {
+		return
+	}
+	shader.SetFloat("alphaCutoff", mat.AlphaCutoff)
+	shader.SetFloat("baseAlpha", mat.BaseColor[3])
+	shader.SetBool("hasBaseColorMap", mat.BaseColorMap != 0)
+	shader.SetInt("baseColorMap", 0)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, mat.BaseColorMap)
+}
+
+// Like Apply, but the textures are bound with the given function so the render queue can skip the ones already bound
+func (mat *Material) apply(shader *Shader, bindTexture func(unit int, id uint32)) {
+	shader.SetVec4("material.baseColor", mat.BaseColor)
+	shader.SetFloat("material.metallic", mat.Metallic)
+	shader.SetFloat("material.roughness", mat.Roughness)
+	shader.SetFloat("material.ao", mat.AO)
+	shader.SetVec3("material.emissive", mat.Emissive)
+	shader.SetFloat("material.reflectivity", mat.Reflectivity)
+	shader.SetFloat("material.refraction", mat.Refraction)
+	shader.SetFloat("material.ior", mat.IOR)
+	shader.SetBool("material.alphaTest", mat.BlendMode == BlendAlphaTest)
+	shader.SetFloat("material.alphaCutoff", mat.AlphaCutoff)
+	mat.applyBlendState()
+
+	maps := []struct {
+		name, flag string
+		id         uint32
+	}{
+		{"material.baseColorMap", "material.hasBaseColorMap", mat.BaseColorMap},
+		{"material.metallicMap", "material.hasMetallicMap", mat.MetallicMap},
+		{"material.roughnessMap", "material.hasRoughnessMap", mat.RoughnessMap},
+		{"material.aoMap", "material.hasAOMap", mat.AOMap},
+		{"material.emissiveMap", "material.hasEmissiveMap", mat.EmissiveMap},
+		{"material.normalMap", "material.hasNormalMap", mat.NormalMap},
+	}
+	for i, m := range maps {
+		bindTexture(i, m.id)
+		shader.SetInt(m.name, i)
+		// The samplers without texture are still bound to their unit, the flag tells the shader to use only the factor
+		shader.SetBool(m.flag, m.id != 0)
+	}
+}
+
+// Function that builds our material from the Assimp one, reading its factors and loading the first texture of each map
+func (m *Model) LoadMaterial(mat *asig.Material) (*Material, error) {
+	material := NewMaterial()
+
+	// Factors, the newer keys are used by glTF and the older ones by formats like OBJ.
+	// We remember which ones are found because the classic ones are only used when there is nothing better
+	var diffuse, shininess []float32
+	var hasBaseColor, hasMetallic, hasRoughness bool
+	alphaMode := ""
+	additive := false
+	for _, property := range mat.Properties {
+		switch property.Name() {
+		case "?mat.name":
+			material.Name = materialPropertyString(property)
+		case "$clr.base", "$mat.gltf.pbrMetallicRoughness.baseColorFactor":
+			if v, ok := materialPropertyFloats(property, 4); ok {
+				material.BaseColor = glm.Vec4{v[0], v[1], v[2], v[3]}
+				hasBaseColor = true
+			}
+		case "$clr.diffuse":
+			diffuse, _ = materialPropertyFloats(property, 3)
+		case "$mat.opacity":
+			if v, ok := materialPropertyFloats(property, 1); ok {
+				material.BaseColor[3] = v[0]
+			}
+		case "$mat.metallicFactor", "$mat.gltf.pbrMetallicRoughness.metallicFactor":
+			if v, ok := materialPropertyFloats(property, 1); ok {
+				material.Metallic = v[0]
+				hasMetallic = true
+			}
+		case "$mat.roughnessFactor", "$mat.gltf.pbrMetallicRoughness.roughnessFactor":
+			if v, ok := materialPropertyFloats(property, 1); ok {
+				material.Roughness = v[0]
+				hasRoughness = true
+			}
+		case "$mat.shininess":
+			shininess, _ = materialPropertyFloats(property, 1)
+		case "$mat.reflectivity":
+			if v, ok := materialPropertyFloats(property, 1); ok {
+				material.Reflectivity = v[0]
+			}
+		case "$mat.refracti":
+			if v, ok := materialPropertyFloats(property, 1); ok && v[0] >= 1 {
+				material.IOR = v[0]
+			}
+		case "$mat.gltf.alphaMode":
+			alphaMode = materialPropertyString(property)
+		case "$mat.gltf.alphaCutoff":
+			if v, ok := materialPropertyFloats(property, 1); ok {
+				material.AlphaCutoff = v[0]
+			}
+		case "$mat.blend":
+			// 1 is the additive blend function of Assimp, 0 the default one
+			additive = property.TypeInfo == asig.MatPropTypeInfoInt32 && len(property.Data) >= 4 && binary.LittleEndian.Uint32(property.Data) == 1
+		case "$clr.emissive":
+			if v, ok := materialPropertyFloats(property, 3); ok {
+				material.Emissive = glm.Vec3{v[0], v[1], v[2]}
+			}
+		}
+	}
+
+	// Maps, for each one we try the PBR texture type first and then the classic ones that are used for the same purpose
+	normalTypes := []asig.TextureType{asig.TextureTypeNormal, asig.TextureTypeNormalCamera}
+	if m.options.HeightAsNormalMap {
+		normalTypes = append(normalTypes, asig.TextureTypeHeight)
+	}
+	maps := []struct {
+		id       *uint32
+		typeName string
+		types    []asig.TextureType
+	}{
+		{&material.BaseColorMap, "texture_base_color", []asig.TextureType{asig.TextureTypeBaseColor, asig.TextureTypeDiffuse}},
+		{&material.MetallicMap, "texture_metallic", []asig.TextureType{asig.TextureTypeMetalness}},
+		{&material.RoughnessMap, "texture_roughness", []asig.TextureType{asig.TextureTypeDiffuseRoughness}},
+		{&material.AOMap, "texture_ao", []asig.TextureType{asig.TextureTypeAmbientOcclusion, asig.TextureTypeAmbient, asig.TextureTypeLightmap}},
+		{&material.EmissiveMap, "texture_emissive", []asig.TextureType{asig.TextureTypeEmissionColor, asig.TextureTypeEmissive}},
+		{&material.NormalMap, "texture_normal", normalTypes},
+	}
+	for _, mp := range maps {
+		for _, textureType := range mp.types {
+			textures, err := m.LoadMaterialTextures(mat, textureType, mp.typeName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load material %q: %w", material.Name, err)
+			}
+			if len(textures) > 0 {
+				*mp.id = uint32(textures[0].id)
+				break
+			}
+		}
+	}
+
+	if material.AOMap == 0 && m.options.AOMap != "" {
+		texture, err := m.loadTexture(m.options.AOMap, "texture_ao")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load material %q: %w", material.Name, err)
+		}
+		material.AOMap = uint32(texture.id)
+	}
+
+	// The maps are multiplied by the factors, so without PBR factors the textured channels use 1.
+	// The diffuse color of the classic materials is only used for the meshes without texture, like Assimp's default grey
+	if !hasBaseColor && diffuse != nil && material.BaseColorMap == 0 {
+		material.BaseColor = glm.Vec4{diffuse[0], diffuse[1], diffuse[2], material.BaseColor[3]}
+	}
+	if !hasMetallic && material.MetallicMap != 0 {
+		material.Metallic = 1.
+	}
+	// The classic materials are dielectrics, the metallic stays 0 and their specular map isn't used as a metalness map
+	if !hasRoughness {
+		if material.RoughnessMap != 0 {
+			material.Roughness = 1.
+		} else if shininess != nil && shininess[0] > 0 {
+			// Formats without roughness only have the Phong exponent, we convert it with the usual Beckmann approximation
+			material.Roughness = float32(math.Sqrt(2. / (float64(shininess[0]) + 2.)))
+		}
+	}
+
+	// glTF says how to use the alpha, the other formats are only transparent if they have opacity
+	switch {
+	case additive:
+		material.SetBlendMode(BlendAdditive)
+	case alphaMode == "MASK":
+		material.SetBlendMode(BlendAlphaTest)
+	case alphaMode == "BLEND", alphaMode == "" && material.BaseColor[3] < 1:
+		material.SetBlendMode(BlendAlphaBlend)
+	}
+
+	return material, nil
+}
+
+// It decodes the first n floats of the property, Assimp stores them in the byte order of the machine, which is little endian in our targets
+func materialPropertyFloats(property *asig.MaterialProperty, n int) ([]float32, bool) {
+	if property.TypeInfo != asig.MatPropTypeInfoFloat32 || len(property.Data) < n*4 {
+		return nil, false
+	}
+	values := make([]float32, n)
+	for i := range values {
+		values[i] = math.Float32frombits(binary.LittleEndian.Uint32(property.Data[i*4:]))
+	}
+	return values, true
+}
+
+// Assimp strings are stored as a 32 bit length followed by the characters
+func materialPropertyString(property *asig.MaterialProperty) string {
+	if property.TypeInfo != asig.MatPropTypeInfoString || len(property.Data) < 4 {
+		return ""
+	}
+	length := int(binary.LittleEndian.Uint32(property.Data))
+	if 4+length > len(property.Data) {
+		return ""
+	}
+	return string(property.Data[4 : 4+length])
+}
@@ -12,12 +12,23 @@ type Vertex struct {
 	Position  glm.Vec3
 	Normal    glm.Vec3
 	TexCoords glm.Vec2
+	// Tangent space of the vertex for the normal maps, together with the normal
+	Tangent   glm.Vec3
+	Bitangent glm.Vec3
+	// Bones that move the vertex and how much each of them does, the unused slots have weight 0
+	BoneIDs [MaxBoneInfluences]int32
+	Weights [MaxBoneInfluences]float32
 }
 
+// First location of the model matrix of each instance, it uses this one and the next three
+const InstanceAttribLocation = 7
+
 type Texture struct {
 	id          uint
 	textureType string
 	path        string
+	// If the texture is a color, stored in sRGB and converted to linear when it is sampled
+	srgb bool
 }
 
 type Mesh struct {
@@ -25,14 +36,30 @@ type Mesh struct {
 	Indices       []uint32
 	Textures      []Texture
 	vao, vbo, ebo uint32
+
+	// Buffer of the model matrices for the instanced draws, it is created the first time and grows when more instances are drawn
+	instanceVBO      uint32
+	instanceCapacity int
+
+	// Box of the vertices in the space of the mesh, for the culling and the picking
+	Bounds AABB
+
+	// If the vertices are moved by the bones of the skeleton of the model
+	Skinned bool
+	// Material for the PBR shader, the Phong shader uses the textures instead
+	Material *Material
 }
 
+// Material of the meshes that don't have one
+var defaultMaterial = NewMaterial()
+
 // Constructor function for the mesh to assign the different values on the mesh vectors
 func NewMesh(vertices []Vertex, indices []uint32, textures []Texture) *Mesh {
 	m := Mesh{
 		Vertices: vertices,
 		Indices:  indices,
 		Textures: textures,
+		Bounds:   verticesBounds(vertices),
 	}
 	m.SetupMesh()
 	return &m
@@ -71,13 +98,59 @@ func (m *Mesh) SetupMesh() {
 	gl.EnableVertexAttribArray(2)
 	gl.VertexAttribPointerWithOffset(2, 2, gl.FLOAT, false, stride, unsafe.Offsetof(Vertex{}.TexCoords))
 
+	// Bone ids, they are integers so we use the I variant to avoid converting them to floats
+	gl.EnableVertexAttribArray(3)
+	gl.VertexAttribIPointerWithOffset(3, MaxBoneInfluences, gl.INT, stride, unsafe.Offsetof(Vertex{}.BoneIDs))
+
+	// Bone weights
+	gl.EnableVertexAttribArray(4)
+	gl.VertexAttribPointerWithOffset(4, MaxBoneInfluences, gl.FLOAT, false, stride, unsafe.Offsetof(Vertex{}.Weights))
+
+	// Tangents and bitangents, after the bones to keep their locations
+	gl.EnableVertexAttribArray(5)
+	gl.VertexAttribPointerWithOffset(5, 3, gl.FLOAT, false, stride, unsafe.Offsetof(Vertex{}.Tangent))
+
+	gl.EnableVertexAttribArray(6)
+	gl.VertexAttribPointerWithOffset(6, 3, gl.FLOAT, false, stride, unsafe.Offsetof(Vertex{}.Bitangent))
+
 	gl.BindVertexArray(0)
 }
 
 func (m *Mesh) Draw(shader Shader) {
+	m.bindMaterial(shader)
+	m.drawElements()
+}
+
+// It draws a copy of the mesh for each of the transforms in a single draw call, the vertex shader multiplies them by the model matrix.
+// The shader must have the instanced uniform enabled, Model.DrawInstanced does it
+func (m *Mesh) DrawInstanced(shader Shader, transforms []glm.Mat4) {
+	if len(transforms) == 0 {
+		return
+	}
+	m.bindMaterial(shader)
+	m.uploadInstances(transforms)
+	gl.BindVertexArray(m.vao)
+	gl.DrawElementsInstanced(gl.TRIANGLES, int32(len(m.Indices)), gl.UNSIGNED_INT, nil, int32(len(transforms)))
+	gl.BindVertexArray(0)
+}
+
+// It binds the material of the mesh, or its textures for the Phong shader
+func (m *Mesh) bindMaterial(shader Shader) {
+	// The PBR shader takes the whole material, the meshes without one use the default factors
+	if _, ok := shader.Uniforms["material.baseColor"]; ok {
+		material := m.Material
+		if material == nil {
+			material = defaultMaterial
+		}
+		material.Apply(&shader)
+		return
+	}
+
 	// It calculates the n-component per texture type and concatenates them to the texture's type string to get the appropiate uniform name
 	var diffuseNr uint = 1
 	var specularNr uint = 1
+	var normalNr uint = 1
+	var heightNr uint = 1
 	for i := 0; i < len(m.Textures); i++ {
 		gl.ActiveTexture(gl.TEXTURE0 + uint32(i)) // Activates the proper texture unit befor binding it
 		var number string
@@ -89,15 +162,133 @@ func (m *Mesh) Draw(shader Shader) {
 		} else if name == "texture_specular" {
 			number = fmt.Sprintf("%v", specularNr)
 			specularNr++
+		} else if name == "texture_normal" {
+			number = fmt.Sprintf("%v", normalNr)
+			normalNr++
+		} else if name == "texture_height" {
+			number = fmt.Sprintf("%v", heightNr)
+			heightNr++
 		}
 		// We locate the appropiate sampler and bind the texture
 		shader.SetInt(("material." + name + number), i)
 		gl.BindTexture(gl.TEXTURE_2D, uint32(m.Textures[i].id))
 	}
 	gl.ActiveTexture(gl.TEXTURE0)
+	// The lighting shader only uses the normal and height maps if the mesh has them
+	if _, ok := shader.Uniforms["material.hasNormalMap"]; ok {
+		shader.SetBool("material.hasNormalMap", normalNr > 1)
+	}
+	if _, ok := shader.Uniforms["material.hasHeightMap"]; ok {
+		shader.SetBool("material.hasHeightMap", heightNr > 1)
+	}
+	// The blending and the alpha test still come from the material
+	material := m.Material
+	if material == nil {
+		material = defaultMaterial
+	}
+	if _, ok := shader.Uniforms["material.alphaTest"]; ok {
+		shader.SetBool("material.alphaTest", material.BlendMode == BlendAlphaTest)
+		shader.SetFloat("material.alphaCutoff", material.AlphaCutoff)
+	}
+	if _, ok := shader.Uniforms["material.parallaxMapping"]; ok {
+		shader.SetBool("material.parallaxMapping", material.ParallaxMapping)
+		shader.SetFloat("material.heightScale", material.HeightScale)
+	}
+	material.applyBlendState()
+}
 
+// It draws the mesh without binding its textures, for the passes that only need the depth
+func (m *Mesh) DrawDepth() {
+	m.drawElements()
+}
+
+// Like DrawInstanced without binding the textures, for the depth passes
+func (m *Mesh) DrawDepthInstanced(transforms []glm.Mat4) {
+	if len(transforms) == 0 {
+		return
+	}
+	m.uploadInstances(transforms)
+	gl.BindVertexArray(m.vao)
+	gl.DrawElementsInstanced(gl.TRIANGLES, int32(len(m.Indices)), gl.UNSIGNED_INT, nil, int32(len(transforms)))
+	gl.BindVertexArray(0)
+}
+
+func (m *Mesh) drawElements() {
 	// draw mesh
 	gl.BindVertexArray(m.vao)
 	gl.DrawElements(gl.TRIANGLES, int32(len(m.Indices)), gl.UNSIGNED_INT, nil)
 	gl.BindVertexArray(0)
 }
+
+// It copies the transforms into the instance buffer, the first time it also sets the attributes of the instances in the vertex array
+func (m *Mesh) uploadInstances(transforms []glm.Mat4) {
+	matSize := int(unsafe.Sizeof(glm.Mat4{}))
+	if m.instanceVBO == 0 {
+		gl.GenBuffers(1, &m.instanceVBO)
+		gl.BindVertexArray(m.vao)
+		gl.BindBuffer(gl.ARRAY_BUFFER, m.instanceVBO)
+		// A mat4 attribute takes four locations, one per column. After the vertex attributes
+		for i := uint32(0); i < 4; i++ {
+			location := InstanceAttribLocation + i
+			gl.EnableVertexAttribArray(location)
+			gl.VertexAttribPointerWithOffset(location, 4, gl.FLOAT, false, int32(matSize), uintptr(i)*4*4)
+			// They advance once per instance instead of once per vertex
+			gl.VertexAttribDivisor(location, 1)
+		}
+		gl.BindVertexArray(0)
+	}
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, m.instanceVBO)
+	// We only reallocate the buffer when it is too small, otherwise we overwrite it
+	if len(transforms) > m.instanceCapacity {
+		m.instanceCapacity = len(transforms)
+		gl.BufferData(gl.ARRAY_BUFFER, m.instanceCapacity*matSize, nil, gl.DYNAMIC_DRAW)
+	}
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(transforms)*matSize, unsafe.Pointer(&transforms[0]))
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+}
+
+// Function that computes the tangents and bitangents of the vertices from the texture coordinates of their triangles,
+// for the meshes that don't have them. The tangent of each vertex is the average of its triangles, made perpendicular to the normal
+func computeTangents(vertices []Vertex, indices []uint32) {
+	tangents := make([]glm.Vec3, len(vertices))
+	bitangents := make([]glm.Vec3, len(vertices))
+
+	for i := 0; i+2 < len(indices); i += 3 {
+		v0, v1, v2 := vertices[indices[i]], vertices[indices[i+1]], vertices[indices[i+2]]
+		edge1 := v1.Position.Sub(v0.Position)
+		edge2 := v2.Position.Sub(v0.Position)
+		deltaUV1 := v1.TexCoords.Sub(v0.TexCoords)
+		deltaUV2 := v2.TexCoords.Sub(v0.TexCoords)
+
+		det := deltaUV1[0]*deltaUV2[1] - deltaUV2[0]*deltaUV1[1]
+		// Triangles without area in texture space can't tell us anything
+		if glm.Abs(det) < 1e-8 {
+			continue
+		}
+		f := 1. / det
+		tangent := edge1.Mul(deltaUV2[1]).Sub(edge2.Mul(deltaUV1[1])).Mul(f)
+		bitangent := edge2.Mul(deltaUV1[0]).Sub(edge1.Mul(deltaUV2[0])).Mul(f)
+		for _, index := range indices[i : i+3] {
+			tangents[index] = tangents[index].Add(tangent)
+			bitangents[index] = bitangents[index].Add(bitangent)
+		}
+	}
+
+	for i := range vertices {
+		normal := vertices[i].Normal
+		// Gram-Schmidt, we remove the part of the tangent that goes along the normal
+		tangent := tangents[i].Sub(normal.Mul(normal.Dot(tangents[i])))
+		if tangent.Len() < 1e-8 {
+			continue
+		}
+		tangent = tangent.Normalize()
+		bitangent := normal.Cross(tangent)
+		// We keep the handedness of the texture coordinates
+		if bitangent.Dot(bitangents[i]) < 0 {
+			bitangent = bitangent.Mul(-1)
+		}
+		vertices[i].Tangent = tangent
+		vertices[i].Bitangent = bitangent
+	}
+}
@@ -1,6 +1,7 @@
 package renderer
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/draw"
@@ -14,62 +15,338 @@ import (
 	glm "github.com/go-gl/mathgl/mgl32"
 )
 
+// Errors that can be returned while loading a model, they can be checked with errors.Is
+var (
+	ErrSceneIncomplete = errors.New("scene is incomplete or has no root node")
+	ErrMissingNormals  = errors.New("mesh has no normals")
+	ErrTextureNotFound = errors.New("texture file not found")
+	ErrTextureDecode   = errors.New("texture could not be decoded")
+	ErrTooManyBones    = errors.New("model has too many bones")
+)
+
+// ModelError is returned when a model can't be imported, it keeps the path of the model file
+type ModelError struct {
+	Path string
+	Err  error
+}
+
+func (e *ModelError) Error() string {
+	return fmt.Sprintf("failed to load model %s: %v", e.Path, e.Err)
+}
+
+func (e *ModelError) Unwrap() error {
+	return e.Err
+}
+
+// MeshError is returned when one of the meshes of the scene can't be processed
+type MeshError struct {
+	Mesh string
+	Err  error
+}
+
+func (e *MeshError) Error() string {
+	return fmt.Sprintf("failed to process mesh %q: %v", e.Mesh, e.Err)
+}
+
+func (e *MeshError) Unwrap() error {
+	return e.Err
+}
+
+// TextureError is returned when a texture referenced by a material can't be loaded
+type TextureError struct {
+	Path string
+	Err  error
+}
+
+func (e *TextureError) Error() string {
+	return fmt.Sprintf("failed to load texture %s: %v", e.Path, e.Err)
+}
+
+func (e *TextureError) Unwrap() error {
+	return e.Err
+}
+
+// ModelOptions controls how the model reacts to the problems found while loading it
+type ModelOptions struct {
+	// If it is true, the textures that can't be loaded are replaced by a placeholder instead of failing the whole model
+	TextureFallback bool
+	// Some formats, like the map_Bump of OBJ, store the normal map as a height map. If it is true those are loaded as normal maps
+	HeightAsNormalMap bool
+	// Ambient occlusion map for the materials that don't have one, relative to the model. OBJ has no key for it
+	AOMap string
+}
+
 type Model struct {
 	meshes          []Mesh
 	directory       string
 	textures_loaded []Texture
+	materials       []*Material
+	options         ModelOptions
+
+	// Root of the node hierarchy of the model, the nodes reference the meshes by their index
+	Root *Node
+	// Transformation of the whole model, it is applied on top of the transformations of the nodes
+	Transform *Transform
+	// Bones of the skinned meshes, it is nil if the model has none, and the animator that moves them
+	Skeleton *Skeleton
+	Animator *Animator
+	// Animations of the file, in the order of the file
+	Clips []*AnimationClip
+
+	// If the model is drawn in the shadow maps and if it is darkened by the shadows of the others
+	CastShadows    bool
+	ReceiveShadows bool
+
+	// Copies of the model, each transform is applied on top of the Transform of the model. DrawInstanced draws them,
+	// and the shadow passes and the raycasts include them
+	Instances []glm.Mat4
 }
 
-func NewModel(path string) *Model {
-	m := &Model{}
-	m.LoadModel(path)
-	return m
+// Loads the model failing if any of its textures can't be loaded
+func NewModel(path string) (*Model, error) {
+	return NewModelWithOptions(path, ModelOptions{})
+}
+
+func NewModelWithOptions(path string, options ModelOptions) (*Model, error) {
+	m := &Model{options: options, Transform: NewTransform(), CastShadows: true, ReceiveShadows: true}
+	if err := m.LoadModel(path); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 func (m *Model) Draw(shader Shader) {
-	for i := 0; i < len(m.meshes); i++ {
-		m.meshes[i].Draw(shader)
+	m.draw(shader, func(mesh *Mesh) {
+		mesh.Draw(shader)
+	})
+}
+
+// It draws a copy of the model for each of the transforms, with one draw call per mesh.
+// Each transform is applied on top of the Transform of the model and its nodes, usually the Transform is left as the identity
+func (m *Model) DrawInstanced(shader Shader, transforms []glm.Mat4) {
+	if len(transforms) == 0 {
+		return
 	}
+	shader.SetBool("instanced", true)
+	m.draw(shader, func(mesh *Mesh) {
+		mesh.DrawInstanced(shader, transforms)
+	})
+	shader.SetBool("instanced", false)
 }
 
-func (m *Model) LoadModel(path string) {
-	// We load the model
-	scene, release, err := asig.ImportFile(path, asig.PostProcessTriangulate|asig.PostProcessFlipUVs)
+// Like Draw, but only the meshes whose box is inside the frustum are drawn. It returns how many were drawn and culled
+func (m *Model) DrawInFrustum(shader Shader, frustum Frustum) (drawn, culled int) {
+	m.draw(shader, func(mesh *Mesh) {
+		mesh.Draw(shader)
+		drawn++
+	}, func(mesh *Mesh, world glm.Mat4) bool {
+		if mesh.Skinned || frustum.IntersectsAABB(mesh.Bounds.Transform(world)) {
+			return true
+		}
+		culled++
+		return false
+	})
+	return drawn, culled
+}
 
-	if err != nil {
-		fmt.Printf("ERROR::ASSIMP::%s\n", err.Error())
+// Box of the whole model in world space. The skinned meshes use the box of their bind pose, so it can be off while they are animated
+func (m *Model) Bounds() AABB {
+	bounds := EmptyAABB()
+	m.walkMeshes(func(mesh *Mesh, world glm.Mat4) {
+		bounds = bounds.Union(mesh.Bounds.Transform(world))
+	})
+	return bounds
+}
+
+// It returns the instances whose copy of the model is at least partly inside the frustum
+func (m *Model) InstancesInFrustum(frustum Frustum) []glm.Mat4 {
+	bounds := m.Bounds()
+	var visible []glm.Mat4
+	for _, instance := range m.Instances {
+		if frustum.IntersectsAABB(bounds.Transform(instance)) {
+			visible = append(visible, instance)
+		}
+	}
+	return visible
+}
+
+func (m *Model) BoundingSphere() Sphere {
+	return m.Bounds().BoundingSphere()
+}
+
+// It draws the meshes that pass all the filters with drawMesh, after setting their matrices
+func (m *Model) draw(shader Shader, drawMesh func(mesh *Mesh), filters ...func(mesh *Mesh, world glm.Mat4) bool) {
+	m.applyUniforms(shader)
+	// The depth shaders don't light anything, so they don't need the normal matrix
+	_, needsNormals := shader.Uniforms["normalMatrix"]
+	// We draw the meshes of every node with its accumulated transformation
+	m.walkMeshes(func(mesh *Mesh, world glm.Mat4) {
+		for _, filter := range filters {
+			if !filter(mesh, world) {
+				return
+			}
+		}
+		shader.SetMat4("model", world)
+		if needsNormals {
+			shader.SetMat3("normalMatrix", normalMatrix(world))
+		}
+		drawMesh(mesh)
+	})
+	// The meshes leave the blending of their material
+	ResetBlendState()
+}
+
+// It sets the uniforms that are the same for all the meshes of the model, only the ones that the shader has
+func (m *Model) applyUniforms(shader Shader) {
+	// Only the lighting shader knows about shadows
+	if _, ok := shader.Uniforms["receiveShadows"]; ok {
+		shader.SetBool("receiveShadows", m.ReceiveShadows)
+	}
+	// Only the skinning and the depth shaders receive the bone matrices
+	if _, ok := shader.Uniforms["boneMatrices"]; ok && m.Skeleton != nil {
+		shader.SetMat4Array("boneMatrices", m.BoneMatrices())
+	}
+}
+
+// It returns the matrices of the bones in the current pose, or the bind pose if the model isn't animated
+func (m *Model) BoneMatrices() []glm.Mat4 {
+	if m.Animator != nil {
+		return m.Animator.BoneMatrices()
+	}
+	return m.Skeleton.BindPose()
+}
+
+// It visits every mesh of every node with the transformation to draw it.
+// The skinned meshes are already placed in the model by their bones, so they only use the transform of the model
+func (m *Model) walkMeshes(visit func(mesh *Mesh, world glm.Mat4)) {
+	base := m.Transform.World()
+	m.Root.Walk(base, func(node *Node, world glm.Mat4) {
+		for _, i := range node.Meshes {
+			if m.meshes[i].Skinned {
+				visit(&m.meshes[i], base)
+			} else {
+				visit(&m.meshes[i], world)
+			}
+		}
+	})
+}
+
+// It draws only the geometry of the model for the depth passes, nothing is drawn if it doesn't cast shadows.
+// The skinned meshes are drawn in the current pose of the animator. The transparent materials don't cast shadows,
+// and the alpha tested ones only where they aren't discarded
+func (m *Model) DrawDepth(shader Shader) {
+	if !m.CastShadows {
 		return
 	}
-	// We check if the scene and the root node of the scene are not null adn check one of its flags to see if the returned data is incomplete
-	if scene.Flags&asig.SceneFlagIncomplete != 0 || scene.RootNode == nil {
-		fmt.Println("ERROR::ASSIMP::Scene is incomplete or has no root node")
-		release()
+	m.applyUniforms(shader)
+	drawMeshes := func(drawMesh func(mesh *Mesh)) {
+		m.walkMeshes(func(mesh *Mesh, world glm.Mat4) {
+			material := mesh.Material
+			if material == nil {
+				material = defaultMaterial
+			}
+			if material.BlendMode.Transparent() {
+				return
+			}
+			material.applyDepth(&shader)
+			shader.SetMat4("model", world)
+			shader.SetBool("skinned", mesh.Skinned)
+			drawMesh(mesh)
+		})
+	}
+	drawMeshes(func(mesh *Mesh) {
+		mesh.DrawDepth()
+	})
+	if len(m.Instances) == 0 {
 		return
 	}
+	shader.SetBool("instanced", true)
+	drawMeshes(func(mesh *Mesh) {
+		mesh.DrawDepthInstanced(m.Instances)
+	})
+	shader.SetBool("instanced", false)
+}
+
+// It returns the node with the given name, so parts of the model can be moved through its Local transformation
+func (m *Model) FindNode(name string) *Node {
+	return m.Root.Find(name)
+}
+
+func (m *Model) LoadModel(path string) error {
+	// We load the model
+	scene, release, err := asig.ImportFile(path, asig.PostProcessTriangulate|asig.PostProcessFlipUVs|asig.PostProcessCalcTangentSpace)
+	if err != nil {
+		return &ModelError{Path: path, Err: err}
+	}
 	defer release()
 
+	// We check if the scene and the root node of the scene are not null adn check one of its flags to see if the returned data is incomplete
+	if scene.Flags&asig.SceneFlagIncomplete != 0 || scene.RootNode == nil {
+		return &ModelError{Path: path, Err: ErrSceneIncomplete}
+	}
+
 	m.directory = filepath.Dir(path)
+	// We load the materials first, the meshes reference them by their index in the scene
+	for i := 0; i < len(scene.Materials); i++ {
+		material, err := m.LoadMaterial(scene.Materials[i])
+		if err != nil {
+			return &ModelError{Path: path, Err: err}
+		}
+		m.materials = append(m.materials, material)
+	}
+	// We process every mesh of the scene once, the nodes reference them by their index so they can be shared
+	for i := 0; i < len(scene.Meshes); i++ {
+		mesh, err := m.ProcessMesh(scene.Meshes[i], scene)
+		if err != nil {
+			return &ModelError{Path: path, Err: err}
+		}
+		m.meshes = append(m.meshes, *mesh)
+	}
 	// If all is good, we process all of the scene's nodes
-	m.ProcessNode(scene.RootNode, scene) // We pass the root node, to process this node, and then process its children nodes
+	m.Root = m.ProcessNode(scene.RootNode, nil) // We pass the root node, to process this node, and then process its children nodes
+	for _, animation := range scene.Animations {
+		m.Clips = append(m.Clips, clipFromAssimp(animation))
+	}
+	return nil
+}
+
+// It returns the clip with the given name, or nil if the file doesn't have it
+func (m *Model) FindClip(name string) *AnimationClip {
+	for _, clip := range m.Clips {
+		if clip.Name == name {
+			return clip
+		}
+	}
+	return nil
 }
 
-func (m *Model) ProcessNode(node *asig.Node, scene *asig.Scene) {
-	// Process all the node's meshes if any
+// It builds our node from the Assimp one keeping its transformation and its meshes, and then does the same for its children
+func (m *Model) ProcessNode(node *asig.Node, parent *Node) *Node {
+	local := glm.Ident4()
+	if node.Transformation != nil {
+		local = mat4FromAssimp(node.Transformation.Data)
+	}
+	n := NewNode(node.Name, local, parent)
 	for i := 0; i < len(node.MeshIndicies); i++ {
-		mesh := scene.Meshes[node.MeshIndicies[i]]
-		m.meshes = append(m.meshes, *m.ProcessMesh(mesh, scene))
+		n.Meshes = append(n.Meshes, int(node.MeshIndicies[i]))
 	}
 	// Then do the same for each of its children
 	for i := 0; i < len(node.Children); i++ {
-		m.ProcessNode(node.Children[i], scene)
+		m.ProcessNode(node.Children[i], n)
 	}
+	return n
 }
 
-func (m *Model) ProcessMesh(mesh *asig.Mesh, scene *asig.Scene) *Mesh {
+func (m *Model) ProcessMesh(mesh *asig.Mesh, scene *asig.Scene) (*Mesh, error) {
 	var vertices []Vertex
 	var indices []uint32
 	var textures []Texture
 
+	// Without normals we can't fill the vertex layout, so we stop here instead of reading out of range
+	if len(mesh.Normals) < len(mesh.Vertices) {
+		return nil, &MeshError{Mesh: mesh.Name, Err: ErrMissingNormals}
+	}
+
 	for i := 0; i < len(mesh.Vertices); i++ {
 		var vertex Vertex
 		var vector glm.Vec3
@@ -88,9 +365,31 @@ func (m *Model) ProcessMesh(mesh *asig.Mesh, scene *asig.Scene) *Mesh {
 		} else {
 			vertex.TexCoords = glm.Vec2{0.0, 0.0}
 		}
+		// Tangent space, Assimp only computes it if the mesh has texture coordinates
+		if i < len(mesh.Tangents) && i < len(mesh.BitTangents) {
+			vertex.Tangent = glm.Vec3{mesh.Tangents[i].X(), mesh.Tangents[i].Y(), mesh.Tangents[i].Z()}
+			vertex.Bitangent = glm.Vec3{mesh.BitTangents[i].X(), mesh.BitTangents[i].Y(), mesh.BitTangents[i].Z()}
+		}
 		// We add the vertex to the vector
 		vertices = append(vertices, vertex)
 	}
+	// We add the influence of each bone to the vertices it moves, the bones are shared by all the meshes of the model
+	for i := 0; i < len(mesh.Bones); i++ {
+		bone := mesh.Bones[i]
+		if m.Skeleton == nil {
+			m.Skeleton = NewSkeleton()
+		}
+		boneID := m.Skeleton.AddBone(bone.Name, mat4FromAssimp(bone.OffsetMatrix.Data))
+		if boneID >= MaxBones {
+			return nil, &MeshError{Mesh: mesh.Name, Err: fmt.Errorf("%w: the maximum is %d", ErrTooManyBones, MaxBones)}
+		}
+		for _, weight := range bone.Weights {
+			vertices[weight.VertIndex].addBoneInfluence(int32(boneID), weight.Weight)
+		}
+	}
+	for i := range vertices {
+		vertices[i].normalizeWeights()
+	}
 	// We iterate through all the mesh and get the indices of the vertices to know in which order they have to be drawn
 	for i := 0; i < len(mesh.Faces); i++ {
 		face := mesh.Faces[i]
@@ -98,79 +397,135 @@ func (m *Model) ProcessMesh(mesh *asig.Mesh, scene *asig.Scene) *Mesh {
 			indices = append(indices, uint32(face.Indices[j]))
 		}
 	}
-	// Here we get all the materials and textures from the model, the diffuse and specular maps, and we add all them to the textures vector
-	if mesh.MaterialIndex >= 0 {
+	// If Assimp didn't give us the tangents we compute them ourselves
+	if len(mesh.Tangents) < len(mesh.Vertices) {
+		computeTangents(vertices, indices)
+	}
+	// Here we get all the materials and textures from the model, the diffuse, specular, normal and height maps, and we add all them to the textures vector
+	if int(mesh.MaterialIndex) < len(scene.Materials) {
 		var material *asig.Material = scene.Materials[mesh.MaterialIndex]
-		var diffuseMaps []Texture = m.LoadMaterialTextures(material, asig.TextureTypeDiffuse, "texture_diffuse")
+		diffuseMaps, err := m.LoadMaterialTextures(material, asig.TextureTypeDiffuse, "texture_diffuse")
+		if err != nil {
+			return nil, &MeshError{Mesh: mesh.Name, Err: err}
+		}
 		textures = append(textures, diffuseMaps...)
 
-		var specularMaps []Texture = m.LoadMaterialTextures(material, asig.TextureTypeSpecular, "texture_specular")
+		specularMaps, err := m.LoadMaterialTextures(material, asig.TextureTypeSpecular, "texture_specular")
+		if err != nil {
+			return nil, &MeshError{Mesh: mesh.Name, Err: err}
+		}
 		textures = append(textures, specularMaps...)
+
+		// The normal maps can come as height maps, in that case we look for the height map in the displacement slot
+		normalType, heightType := asig.TextureTypeNormal, asig.TextureTypeHeight
+		if m.options.HeightAsNormalMap {
+			normalType, heightType = asig.TextureTypeHeight, asig.TextureTypeDisplacement
+		}
+		normalMaps, err := m.LoadMaterialTextures(material, normalType, "texture_normal")
+		if err != nil {
+			return nil, &MeshError{Mesh: mesh.Name, Err: err}
+		}
+		textures = append(textures, normalMaps...)
+
+		heightMaps, err := m.LoadMaterialTextures(material, heightType, "texture_height")
+		if err != nil {
+			return nil, &MeshError{Mesh: mesh.Name, Err: err}
+		}
+		textures = append(textures, heightMaps...)
 	}
 	// Finally we create a mesh with all the data saved early
-	return NewMesh(vertices, indices, textures)
+	result := NewMesh(vertices, indices, textures)
+	result.Skinned = len(mesh.Bones) > 0
+	if int(mesh.MaterialIndex) < len(m.materials) {
+		result.Material = m.materials[mesh.MaterialIndex]
+	}
+	return result, nil
 }
 
 // Function to load the textures from the model
-func (m *Model) LoadMaterialTextures(mat *asig.Material, mType asig.TextureType, typeName string) []Texture {
+func (m *Model) LoadMaterialTextures(mat *asig.Material, mType asig.TextureType, typeName string) ([]Texture, error) {
 	var textures []Texture
 	count := asig.GetMaterialTextureCount(mat, mType)
 	// We iterate through all the textures
 	for i := 0; i < count; i++ {
 		// We get the path of the textures
 		path, err := asig.GetMaterialTexture(mat, mType, uint(i))
-		// This var is to skip any texture if it is repeated
-		var skip bool = false
 		if err != nil {
-			fmt.Printf("%s", "Error loading the texture from path: "+path.Path)
-			continue
-		}
-		// It checks if the texture that we have saved is the same that we have saved in our textures_loaded variable, If it is repeated, we load that saved texture
-		for j := 0; j < len(m.textures_loaded); j++ {
-			if m.textures_loaded[j].path == path.Path {
-				textures = append(textures, m.textures_loaded[j])
-				skip = true
-				break
-			}
+			return nil, fmt.Errorf("failed to get %s %d from the material: %w", typeName, i, err)
 		}
-		// If the texture doesn't have to be skipped we get access to the texture features and save them in our texture var, then we add this texture to the vector of textures and to the vector of loaded textures to not load it again
-		if !skip {
-			var texture Texture
-			id, err := TextureFromFile(path.Path, m.directory)
-			if err != nil {
-				fmt.Printf("Failed to load texture from the file: %s", path.Path)
-			}
-			texture.id = uint(id)
-			texture.textureType = typeName
-			texture.path = path.Path
-			textures = append(textures, texture)
-			m.textures_loaded = append(m.textures_loaded, texture)
+		texture, err := m.loadTexture(path.Path, typeName)
+		if err != nil {
+			return nil, err
 		}
+		textures = append(textures, texture)
 	}
 	// We return all the textures
-	return textures
+	return textures, nil
 }
 
-// Function that reads the textures and processes them
+// It loads the texture at the path relative to the model, or reuses it if it was already loaded
+func (m *Model) loadTexture(path string, typeName string) (Texture, error) {
+	// The color maps are stored in sRGB, the others hold data like normals that is already linear
+	srgb := isColorMap(typeName)
+	// It checks if the texture that we have saved is the same that we have saved in our textures_loaded variable, If it is repeated, we load that saved texture
+	for j := 0; j < len(m.textures_loaded); j++ {
+		if m.textures_loaded[j].path == path && m.textures_loaded[j].srgb == srgb {
+			// The same file can be used for different purposes, so we keep the type that is asked now
+			texture := m.textures_loaded[j]
+			texture.textureType = typeName
+			return texture, nil
+		}
+	}
+	// Otherwise we load it and add it to the vector of loaded textures to not load it again
+	var texture Texture
+	load := TextureFromFile
+	if srgb {
+		load = ColorTextureFromFile
+	}
+	id, err := load(path, m.directory)
+	if err != nil {
+		// If the fallback is enabled we report the problem and use the placeholder so the mesh can still be drawn
+		if !m.options.TextureFallback {
+			return Texture{}, err
+		}
+		fmt.Printf("Warning: %v, using placeholder texture\n", err)
+		id = PlaceholderTexture()
+	}
+	texture.id = uint(id)
+	texture.textureType = typeName
+	texture.path = path
+	texture.srgb = srgb
+	m.textures_loaded = append(m.textures_loaded, texture)
+	return texture, nil
+}
+
+// If the maps of the type are colors, which the images store in sRGB
+func isColorMap(typeName string) bool {
+	return typeName == "texture_diffuse" || typeName == "texture_base_color" || typeName == "texture_emissive"
+}
+
+// Function that loads an image with data that is already linear, like normal, metallic, roughness and AO maps
 func TextureFromFile(path string, directory string) (uint32, error) {
+	return textureFromFile(path, directory, gl.RGBA)
+}
+
+// Function that loads an image with colors, like diffuse and emissive maps. They are stored in sRGB and OpenGL converts
+// them to linear when they are sampled, so the lighting is done in linear space and the gamma pass doesn't apply gamma twice
+func ColorTextureFromFile(path string, directory string) (uint32, error) {
+	return textureFromFile(path, directory, gl.SRGB8_ALPHA8)
+}
+
+// Function that reads the textures and processes them
+func textureFromFile(path string, directory string, internalFormat int32) (uint32, error) {
 	// We get the file name of the texture
 	fileName := filepath.Join(directory, path)
 
-	// We open and decode the image
-	imgFile, err := os.Open(fileName)
-	if err != nil {
-		return 0, fmt.Errorf("Failed to open texture file %v", err)
-	}
-	defer imgFile.Close()
-	img, _, err := image.Decode(imgFile)
+	// We open and decode the image into RGBA pixels
+	rgba, err := loadRGBA(fileName)
 	if err != nil {
-		return 0, fmt.Errorf("Failed to decode image %v", err)
+		return 0, err
 	}
 
-	// Convert the image saved into RGBA pixels
-	rgba := image.NewRGBA(img.Bounds())
-	draw.Draw(rgba, rgba.Bounds(), img, image.Point{0, 0}, draw.Src)
-
 	// Flip the pixels vertically
 	flipVertical(rgba)
 
@@ -188,7 +543,7 @@ func TextureFromFile(path string, directory string) (uint32, error) {
 	pix := gl.Ptr(rgba.Pix)
 
 	// We set the values for OpenGL to know how the texture is
-	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, width, height, 0, gl.RGBA, gl.UNSIGNED_BYTE, pix)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, internalFormat, width, height, 0, gl.RGBA, gl.UNSIGNED_BYTE, pix)
 	gl.GenerateMipmap(gl.TEXTURE_2D)
 
 	// Set parameters
@@ -201,6 +556,47 @@ func TextureFromFile(path string, directory string) (uint32, error) {
 	return textureID, nil
 }
 
+// Function that opens and decodes an image file into RGBA pixels, the errors are TextureErrors
+func loadRGBA(fileName string) (*image.RGBA, error) {
+	imgFile, err := os.Open(fileName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("%w: %w", ErrTextureNotFound, err)
+		}
+		return nil, &TextureError{Path: fileName, Err: err}
+	}
+	defer imgFile.Close()
+	img, _, err := image.Decode(imgFile)
+	if err != nil {
+		return nil, &TextureError{Path: fileName, Err: fmt.Errorf("%w: %w", ErrTextureDecode, err)}
+	}
+
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba, nil
+}
+
+// Placeholder texture used when a texture can't be loaded, it is created the first time it is needed
+var placeholderTexture uint32
+
+// Function that returns a 1x1 magenta texture so the missing textures are easy to spot
+func PlaceholderTexture() uint32 {
+	if placeholderTexture != 0 {
+		return placeholderTexture
+	}
+	pixel := []uint8{255, 0, 255, 255}
+
+	gl.GenTextures(1, &placeholderTexture)
+	gl.BindTexture(gl.TEXTURE_2D, placeholderTexture)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, 1, 1, 0, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(pixel))
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.REPEAT)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.REPEAT)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+
+	return placeholderTexture
+}
+
 func flipVertical(rgba *image.RGBA) {
 	height := rgba.Rect.Dy()
 	// Calculates the stride of each row
@@ -0,0 +1,96 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadErrors(t *testing.T) {
+	decode := errors.New("unexpected EOF")
+	tests := []struct {
+		name    string
+		err     error
+		message string
+		// Errors that errors.Is must find in the chain
+		is []error
+	}{
+		{"model", &ModelError{Path: "backpack.obj", Err: ErrSceneIncomplete},
+			"failed to load model backpack.obj: scene is incomplete or has no root node", []error{ErrSceneIncomplete}},
+		{"mesh", &MeshError{Mesh: "body", Err: fmt.Errorf("%w: the maximum is %d", ErrTooManyBones, MaxBones)},
+			fmt.Sprintf("failed to process mesh \"body\": model has too many bones: the maximum is %d", MaxBones), []error{ErrTooManyBones}},
+		{"texture", &TextureError{Path: "diffuse.jpg", Err: fmt.Errorf("%w: %w", ErrTextureDecode, decode)},
+			"failed to load texture diffuse.jpg: texture could not be decoded: unexpected EOF", []error{ErrTextureDecode, decode}},
+		// A texture that fails inside a mesh that fails inside the model
+		{"nested", &ModelError{Path: "backpack.obj", Err: &MeshError{Mesh: "body", Err: &TextureError{Path: "diffuse.jpg", Err: ErrTextureNotFound}}},
+			"failed to load model backpack.obj: failed to process mesh \"body\": failed to load texture diffuse.jpg: texture file not found",
+			[]error{ErrTextureNotFound}},
+		{"hdr", &TextureError{Path: "sky.hdr", Err: fmt.Errorf("%w: %w", ErrTextureDecode, fmt.Errorf("%w: bad run", ErrInvalidHDR))},
+			"failed to load texture sky.hdr: texture could not be decoded: invalid Radiance HDR file: bad run", []error{ErrTextureDecode, ErrInvalidHDR}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.err.Error() != test.message {
+				t.Errorf("got the message %q, want %q", test.err.Error(), test.message)
+			}
+			for _, target := range test.is {
+				if !errors.Is(test.err, target) {
+					t.Errorf("errors.Is doesn't find %q in %q", target, test.err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadErrorsAs(t *testing.T) {
+	err := error(&ModelError{Path: "backpack.obj", Err: &MeshError{Mesh: "body", Err: &TextureError{Path: "diffuse.jpg", Err: ErrTextureNotFound}}})
+
+	var modelErr *ModelError
+	var meshErr *MeshError
+	var textureErr *TextureError
+	if !errors.As(err, &modelErr) || modelErr.Path != "backpack.obj" {
+		t.Errorf("errors.As doesn't find the ModelError, got %v", modelErr)
+	}
+	if !errors.As(err, &meshErr) || meshErr.Mesh != "body" {
+		t.Errorf("errors.As doesn't find the MeshError, got %v", meshErr)
+	}
+	if !errors.As(err, &textureErr) || textureErr.Path != "diffuse.jpg" {
+		t.Errorf("errors.As doesn't find the TextureError, got %v", textureErr)
+	}
+	if errors.Is(err, ErrTextureDecode) {
+		t.Errorf("errors.Is finds %q in %q", ErrTextureDecode, err)
+	}
+}
+
+func TestLoadRGBAErrors(t *testing.T) {
+	dir := t.TempDir()
+	notImage := filepath.Join(dir, "text.png")
+	if err := os.WriteFile(notImage, []byte("not an image"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		path string
+		is   []error
+	}{
+		{"missing", filepath.Join(dir, "missing.png"), []error{ErrTextureNotFound, fs.ErrNotExist}},
+		{"not an image", notImage, []error{ErrTextureDecode}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := loadRGBA(test.path)
+			var textureErr *TextureError
+			if !errors.As(err, &textureErr) || textureErr.Path != test.path {
+				t.Fatalf("got %v, want a TextureError of %s", err, test.path)
+			}
+			for _, target := range test.is {
+				if !errors.Is(err, target) {
+					t.Errorf("errors.Is doesn't find %q in %q", target, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package renderer
+
+import (
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Node of the hierarchy of a model, it keeps the transformation relative to its parent and the meshes that it draws
+type Node struct {
+	Name     string
+	Local    glm.Mat4
+	Parent   *Node
+	Children []*Node
+	// Indices of the meshes of the model that are drawn with the transformation of this node
+	Meshes []int
+}
+
+func NewNode(name string, local glm.Mat4, parent *Node) *Node {
+	n := &Node{
+		Name:   name,
+		Local:  local,
+		Parent: parent,
+	}
+	if parent != nil {
+		parent.Children = append(parent.Children, n)
+	}
+	return n
+}
+
+// It returns the transformation of the node relative to the root of the hierarchy
+func (n *Node) World() glm.Mat4 {
+	world := n.Local
+	for parent := n.Parent; parent != nil; parent = parent.Parent {
+		world = parent.Local.Mul4(world)
+	}
+	return world
+}
+
+// It looks for the node with the given name in this node and its descendants, it returns nil if there is none
+func (n *Node) Find(name string) *Node {
+	if n.Name == name {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := child.Find(name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// It visits the node and its descendants depth first, passing the transformation of each one accumulated on top of parent
+func (n *Node) Walk(parent glm.Mat4, visit func(node *Node, world glm.Mat4)) {
+	world := parent.Mul4(n.Local)
+	visit(n, world)
+	for _, child := range n.Children {
+		child.Walk(world, visit)
+	}
+}
+
+// Assimp stores the matrices by columns like mgl32, so we only need to flatten them
+func mat4FromAssimp(data [4][4]float32) glm.Mat4 {
+	var m glm.Mat4
+	for col := 0; col < 4; col++ {
+		for row := 0; row < 4; row++ {
+			m[col*4+row] = data[col][row]
+		}
+	}
+	return m
+}
@@ -0,0 +1,385 @@
+package renderer
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Full screen effect applied to the rendered image before it goes to the window
+type PostProcess interface {
+	// It draws the effect of the input texture into the output framebuffer, or into the window of the given size if output is nil
+	Render(input uint32, output *Framebuffer, width, height int32)
+	Enabled() bool
+	SetEnabled(enabled bool)
+	Delete()
+}
+
+// Part shared by the passes, its shader and if it is enabled
+type postPass struct {
+	shader  *Shader
+	enabled bool
+}
+
+func (p *postPass) Enabled() bool           { return p.enabled }
+func (p *postPass) SetEnabled(enabled bool) { p.enabled = enabled }
+func (p *postPass) Delete()                 { p.shader.Delete() }
+
+// It binds the output, the shader and the input at unit 0
+func (p *postPass) begin(input uint32, output *Framebuffer, width, height int32) {
+	bindOutput(output, width, height)
+	p.shader.Use()
+	p.shader.SetInt("screenTexture", 0)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, input)
+}
+
+func bindOutput(output *Framebuffer, width, height int32) {
+	if output != nil {
+		output.Bind()
+	} else {
+		BindDefaultFramebuffer(width, height)
+	}
+}
+
+// It creates the shader of a pass with the full screen vertex shader
+func newPostShader(shaderDir, fragment string) (*Shader, error) {
+	shader, err := NewShader(filepath.Join(shaderDir, "fullscreenVShader.glsl"), filepath.Join(shaderDir, fragment))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the post processing shader %s: %w", fragment, err)
+	}
+	return shader, nil
+}
+
+// Curve that takes the HDR colors to the [0, 1] range of the screen
+type ToneMapOperator int
+
+// They must match the values in toneMappingFShader.glsl
+const (
+	ToneMapACES ToneMapOperator = iota
+	ToneMapReinhard
+	// Only the exposure, the colors over 1 are clamped
+	ToneMapNone
+)
+
+type ToneMappingPass struct {
+	postPass
+	Exposure float32
+	Operator ToneMapOperator
+}
+
+func NewToneMappingPass(shaderDir string) (*ToneMappingPass, error) {
+	shader, err := newPostShader(shaderDir, "toneMappingFShader.glsl")
+	if err != nil {
+		return nil, err
+	}
+	return &ToneMappingPass{postPass: postPass{shader: shader, enabled: true}, Exposure: 1., Operator: ToneMapACES}, nil
+}
+
+func (p *ToneMappingPass) Render(input uint32, output *Framebuffer, width, height int32) {
+	p.begin(input, output, width, height)
+	p.shader.SetFloat("exposure", p.Exposure)
+	p.shader.SetInt("toneMapOperator", int(p.Operator))
+	DrawFullscreenTriangle()
+}
+
+// It takes the linear colors of the lighting to the sRGB space of the screen, it must go after the tone mapping
+type GammaPass struct {
+	postPass
+	Gamma float32
+}
+
+func NewGammaPass(shaderDir string) (*GammaPass, error) {
+	shader, err := newPostShader(shaderDir, "gammaFShader.glsl")
+	if err != nil {
+		return nil, err
+	}
+	return &GammaPass{postPass: postPass{shader: shader, enabled: true}, Gamma: 2.2}, nil
+}
+
+func (p *GammaPass) Render(input uint32, output *Framebuffer, width, height int32) {
+	p.begin(input, output, width, height)
+	p.shader.SetFloat("gamma", p.Gamma)
+	DrawFullscreenTriangle()
+}
+
+// Glow around the bright areas. It keeps the light over the threshold, blurs it with a dual Kawase blur
+// going down and up a chain of smaller framebuffers, and adds it to the image. It must go before the tone mapping
+type BloomPass struct {
+	postPass
+	Threshold float32
+	// Soft transition around the threshold, from 0 to 1
+	Knee      float32
+	Intensity float32
+	// Levels of the chain, each one is half the size of the previous one and makes the blur wider
+	Levels int
+
+	down, up, composite *Shader
+	chain               []*Framebuffer
+}
+
+func NewBloomPass(shaderDir string) (*BloomPass, error) {
+	p := &BloomPass{Threshold: 1., Knee: 0.5, Intensity: 0.5, Levels: 5}
+	p.enabled = true
+	var err error
+	for _, s := range []struct {
+		shader **Shader
+		file   string
+	}{
+		{&p.shader, "bloomBrightFShader.glsl"},
+		{&p.down, "bloomDownFShader.glsl"},
+		{&p.up, "bloomUpFShader.glsl"},
+		{&p.composite, "bloomCompositeFShader.glsl"},
+	} {
+		if *s.shader, err = newPostShader(shaderDir, s.file); err != nil {
+			p.Delete()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (p *BloomPass) Render(input uint32, output *Framebuffer, width, height int32) {
+	if err := p.resizeChain(width, height); err != nil {
+		fmt.Printf("Warning: bloom disabled: %v\n", err)
+		p.enabled = false
+		return
+	}
+
+	// Bright pass into the first level, at half the size
+	p.begin(input, p.chain[0], width, height)
+	p.shader.SetFloat("threshold", p.Threshold)
+	p.shader.SetFloat("knee", p.Knee)
+	DrawFullscreenTriangle()
+
+	// Down the chain, each level blurs the previous one
+	p.down.Use()
+	p.down.SetInt("screenTexture", 0)
+	for i := 1; i < len(p.chain); i++ {
+		p.chain[i].Bind()
+		p.down.SetVec2("texelSize", texelSize(p.chain[i-1]))
+		gl.BindTexture(gl.TEXTURE_2D, p.chain[i-1].ColorAttachments[0])
+		DrawFullscreenTriangle()
+	}
+	// And up again to the first level
+	p.up.Use()
+	p.up.SetInt("screenTexture", 0)
+	for i := len(p.chain) - 1; i > 0; i-- {
+		p.chain[i-1].Bind()
+		p.up.SetVec2("texelSize", texelSize(p.chain[i]))
+		gl.BindTexture(gl.TEXTURE_2D, p.chain[i].ColorAttachments[0])
+		DrawFullscreenTriangle()
+	}
+
+	bindOutput(output, width, height)
+	p.composite.Use()
+	p.composite.SetInt("screenTexture", 0)
+	p.composite.SetInt("bloomTexture", 1)
+	p.composite.SetFloat("intensity", p.Intensity)
+	gl.BindTexture(gl.TEXTURE_2D, input)
+	gl.ActiveTexture(gl.TEXTURE1)
+	gl.BindTexture(gl.TEXTURE_2D, p.chain[0].ColorAttachments[0])
+	gl.ActiveTexture(gl.TEXTURE0)
+	DrawFullscreenTriangle()
+}
+
+// It creates the levels of the chain, or resizes them if the screen or the number of levels changed
+func (p *BloomPass) resizeChain(width, height int32) error {
+	levels := max(p.Levels, 1)
+	for len(p.chain) > levels {
+		p.chain[len(p.chain)-1].Delete()
+		p.chain = p.chain[:len(p.chain)-1]
+	}
+	for i := 0; i < levels; i++ {
+		w, h := max(width>>(i+1), 1), max(height>>(i+1), 1)
+		if i < len(p.chain) {
+			if err := p.chain[i].Resize(w, h); err != nil {
+				return err
+			}
+			continue
+		}
+		level, err := NewFramebuffer(FramebufferConfig{Width: w, Height: h, Colors: []ColorAttachment{ColorRGBA16F}})
+		if err != nil {
+			return err
+		}
+		p.chain = append(p.chain, level)
+	}
+	return nil
+}
+
+func (p *BloomPass) Delete() {
+	for _, shader := range []*Shader{p.shader, p.down, p.up, p.composite} {
+		if shader != nil {
+			shader.Delete()
+		}
+	}
+	for _, level := range p.chain {
+		level.Delete()
+	}
+	p.chain = nil
+}
+
+// Fast approximate anti-aliasing, it smooths the edges of the final image. It must go after the tone mapping and gamma
+type FXAAPass struct {
+	postPass
+}
+
+func NewFXAAPass(shaderDir string) (*FXAAPass, error) {
+	shader, err := newPostShader(shaderDir, "fxaaFShader.glsl")
+	if err != nil {
+		return nil, err
+	}
+	return &FXAAPass{postPass{shader: shader, enabled: true}}, nil
+}
+
+func (p *FXAAPass) Render(input uint32, output *Framebuffer, width, height int32) {
+	p.begin(input, output, width, height)
+	p.shader.SetVec2("texelSize", glm.Vec2{1. / float32(width), 1. / float32(height)})
+	DrawFullscreenTriangle()
+}
+
+// It darkens the corners of the image
+type VignettePass struct {
+	postPass
+	Intensity float32
+	// Distance from the center where the darkening ends and how wide it is, in texture coordinates
+	Radius   float32
+	Softness float32
+}
+
+func NewVignettePass(shaderDir string) (*VignettePass, error) {
+	shader, err := newPostShader(shaderDir, "vignetteFShader.glsl")
+	if err != nil {
+		return nil, err
+	}
+	return &VignettePass{postPass: postPass{shader: shader, enabled: true}, Intensity: 0.5, Radius: 0.75, Softness: 0.45}, nil
+}
+
+func (p *VignettePass) Render(input uint32, output *Framebuffer, width, height int32) {
+	p.begin(input, output, width, height)
+	p.shader.SetFloat("intensity", p.Intensity)
+	p.shader.SetFloat("radius", p.Radius)
+	p.shader.SetFloat("softness", p.Softness)
+	DrawFullscreenTriangle()
+}
+
+// Ordered chain of passes. Each enabled pass reads the output of the previous one from one of two HDR framebuffers,
+// and the last one draws into the window
+type PostProcessStack struct {
+	Passes []PostProcess
+
+	pingPong [2]*Framebuffer
+	copy     *Shader
+}
+
+// Function that creates the stack with the built-in passes in their order: bloom, tone mapping, gamma, FXAA and vignette
+func NewPostProcessStack(shaderDir string, width, height int32) (*PostProcessStack, error) {
+	s := &PostProcessStack{}
+	var err error
+	// Without passes the image is copied as it is
+	if s.copy, err = newPostShader(shaderDir, "copyFShader.glsl"); err != nil {
+		return nil, err
+	}
+	for i := range s.pingPong {
+		s.pingPong[i], err = NewFramebuffer(FramebufferConfig{Width: width, Height: height, Colors: []ColorAttachment{ColorRGBA16F}})
+		if err != nil {
+			s.Delete()
+			return nil, err
+		}
+	}
+
+	constructors := []func(string) (PostProcess, error){
+		func(dir string) (PostProcess, error) { return NewBloomPass(dir) },
+		func(dir string) (PostProcess, error) { return NewToneMappingPass(dir) },
+		func(dir string) (PostProcess, error) { return NewGammaPass(dir) },
+		func(dir string) (PostProcess, error) { return NewFXAAPass(dir) },
+		func(dir string) (PostProcess, error) { return NewVignettePass(dir) },
+	}
+	for _, newPass := range constructors {
+		pass, err := newPass(shaderDir)
+		if err != nil {
+			s.Delete()
+			return nil, err
+		}
+		s.Passes = append(s.Passes, pass)
+	}
+	return s, nil
+}
+
+// It returns the first pass of the type T in the stack, to change its parameters
+func FindPass[T PostProcess](s *PostProcessStack) (T, bool) {
+	for _, pass := range s.Passes {
+		if p, ok := pass.(T); ok {
+			return p, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func (s *PostProcessStack) Resize(width, height int32) error {
+	for _, framebuffer := range s.pingPong {
+		if err := framebuffer.Resize(width, height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// It runs the enabled passes over the input texture and draws the result into the window of the given size
+func (s *PostProcessStack) Render(input uint32, width, height int32) {
+	s.RenderTo(input, nil, width, height)
+}
+
+// Like Render, but the result goes to the output framebuffer, like the one that is captured in headless mode
+func (s *PostProcessStack) RenderTo(input uint32, output *Framebuffer, width, height int32) {
+	// The passes draw over the whole screen
+	gl.Disable(gl.DEPTH_TEST)
+	defer gl.Enable(gl.DEPTH_TEST)
+
+	var enabled []PostProcess
+	for _, pass := range s.Passes {
+		if pass.Enabled() {
+			enabled = append(enabled, pass)
+		}
+	}
+	if len(enabled) == 0 {
+		bindOutput(output, width, height)
+		s.copy.Use()
+		s.copy.SetInt("screenTexture", 0)
+		gl.ActiveTexture(gl.TEXTURE0)
+		gl.BindTexture(gl.TEXTURE_2D, input)
+		DrawFullscreenTriangle()
+		return
+	}
+
+	for i, pass := range enabled {
+		target := output
+		if i < len(enabled)-1 {
+			target = s.pingPong[i%2]
+		}
+		pass.Render(input, target, width, height)
+		input = s.pingPong[i%2].ColorAttachments[0]
+	}
+	BindDefaultFramebuffer(width, height)
+}
+
+func (s *PostProcessStack) Delete() {
+	for _, pass := range s.Passes {
+		pass.Delete()
+	}
+	for _, framebuffer := range s.pingPong {
+		if framebuffer != nil {
+			framebuffer.Delete()
+		}
+	}
+	if s.copy != nil {
+		s.copy.Delete()
+	}
+}
+
+func texelSize(f *Framebuffer) glm.Vec2 {
+	return glm.Vec2{1. / float32(f.Width), 1. / float32(f.Height)}
+}
@@ -0,0 +1,90 @@
+package renderer
+
+import (
+	"unsafe"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+// Geometry shared by the passes that don't draw models, it is created the first time it is needed
+var (
+	cubeVAO, cubeVBO uint32
+	quadVAO, quadVBO uint32
+	triangleVAO      uint32
+)
+
+// It draws a cube from -1 to 1 with only positions at location 0, used to render cubemaps and the skybox
+func DrawCube() {
+	if cubeVAO == 0 {
+		vertices := []float32{
+			// Back face
+			-1, -1, -1, 1, 1, -1, 1, -1, -1,
+			1, 1, -1, -1, -1, -1, -1, 1, -1,
+			// Front face
+			-1, -1, 1, 1, -1, 1, 1, 1, 1,
+			1, 1, 1, -1, 1, 1, -1, -1, 1,
+			// Left face
+			-1, 1, 1, -1, 1, -1, -1, -1, -1,
+			-1, -1, -1, -1, -1, 1, -1, 1, 1,
+			// Right face
+			1, 1, 1, 1, -1, -1, 1, 1, -1,
+			1, -1, -1, 1, 1, 1, 1, -1, 1,
+			// Bottom face
+			-1, -1, -1, 1, -1, -1, 1, -1, 1,
+			1, -1, 1, -1, -1, 1, -1, -1, -1,
+			// Top face
+			-1, 1, -1, 1, 1, 1, 1, 1, -1,
+			1, 1, 1, -1, 1, -1, -1, 1, 1,
+		}
+		cubeVAO, cubeVBO = newPositionsVAO(vertices, 3)
+	}
+	gl.BindVertexArray(cubeVAO)
+	gl.DrawArrays(gl.TRIANGLES, 0, 36)
+	gl.BindVertexArray(0)
+}
+
+// It draws a quad that covers the screen, with positions at location 0 and texture coordinates at location 1
+func DrawQuad() {
+	if quadVAO == 0 {
+		vertices := []float32{
+			-1, 1, 0, 0, 1,
+			-1, -1, 0, 0, 0,
+			1, 1, 0, 1, 1,
+			1, -1, 0, 1, 0,
+		}
+		quadVAO, quadVBO = newPositionsVAO(vertices, 5)
+		gl.BindVertexArray(quadVAO)
+		gl.EnableVertexAttribArray(1)
+		gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, 5*4, 3*4)
+		gl.BindVertexArray(0)
+	}
+	gl.BindVertexArray(quadVAO)
+	gl.DrawArrays(gl.TRIANGLE_STRIP, 0, 4)
+	gl.BindVertexArray(0)
+}
+
+// It draws a triangle that covers the screen, for the full screen passes. It is faster than a quad because there is no diagonal
+// where the fragments are shaded twice. It has no vertices, the vertex shader makes them from gl_VertexID
+func DrawFullscreenTriangle() {
+	// The core profile still needs a vertex array bound to draw
+	if triangleVAO == 0 {
+		gl.GenVertexArrays(1, &triangleVAO)
+	}
+	gl.BindVertexArray(triangleVAO)
+	gl.DrawArrays(gl.TRIANGLES, 0, 3)
+	gl.BindVertexArray(0)
+}
+
+// It uploads the vertices and sets the first three floats of each one as the position
+func newPositionsVAO(vertices []float32, floatsPerVertex int32) (uint32, uint32) {
+	var vao, vbo uint32
+	gl.GenVertexArrays(1, &vao)
+	gl.GenBuffers(1, &vbo)
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*int(unsafe.Sizeof(float32(0))), gl.Ptr(vertices), gl.STATIC_DRAW)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointerWithOffset(0, 3, gl.FLOAT, false, floatsPerVertex*4, 0)
+	gl.BindVertexArray(0)
+	return vao, vbo
+}
@@ -0,0 +1,85 @@
+package renderer
+
+import (
+	"math"
+
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Half line from the origin, the direction must be normalized so the distances along it are real distances
+type Ray struct {
+	Origin    glm.Vec3
+	Direction glm.Vec3
+}
+
+// Point of the ray at the given distance
+func (r Ray) At(distance float32) glm.Vec3 {
+	return r.Origin.Add(r.Direction.Mul(distance))
+}
+
+// It returns the ray after the transformation, the direction is normalized again in case the matrix scales
+func (r Ray) Transform(m glm.Mat4) Ray {
+	origin := m.Mul4x1(r.Origin.Vec4(1)).Vec3()
+	direction := m.Mul4x1(r.Direction.Vec4(0)).Vec3()
+	return Ray{Origin: origin, Direction: direction.Normalize()}
+}
+
+// Slab test, it returns the distance where the ray enters the box, 0 if it starts inside
+func (r Ray) IntersectAABB(box AABB) (float32, bool) {
+	if box.IsEmpty() {
+		return 0, false
+	}
+	tMin := float32(0)
+	tMax := float32(math.Inf(1))
+	for i := 0; i < 3; i++ {
+		// Dividing by 0 gives infinities with the right sign, so the parallel axes only pass if the origin is between the planes
+		inv := 1 / r.Direction[i]
+		t1 := (box.Min[i] - r.Origin[i]) * inv
+		t2 := (box.Max[i] - r.Origin[i]) * inv
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		// NaN happens when the origin is on the plane of a parallel axis, then the axis doesn't limit anything
+		if !math.IsNaN(float64(t1)) {
+			tMin = max(tMin, t1)
+		}
+		if !math.IsNaN(float64(t2)) {
+			tMax = min(tMax, t2)
+		}
+		if tMin > tMax {
+			return 0, false
+		}
+	}
+	return tMin, true
+}
+
+// Möller–Trumbore ray-triangle intersection. It returns the distance and the barycentric coordinates u and v of the hit,
+// the weights of v1 and v2, the one of v0 is 1 - u - v. Both faces of the triangle are hit
+func (r Ray) IntersectTriangle(v0, v1, v2 glm.Vec3) (distance, u, v float32, ok bool) {
+	const epsilon = 1e-7
+	edge1 := v1.Sub(v0)
+	edge2 := v2.Sub(v0)
+	p := r.Direction.Cross(edge2)
+	det := edge1.Dot(p)
+	// The ray is parallel to the triangle
+	if det > -epsilon && det < epsilon {
+		return 0, 0, 0, false
+	}
+	invDet := 1 / det
+
+	s := r.Origin.Sub(v0)
+	u = s.Dot(p) * invDet
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+	q := s.Cross(edge1)
+	v = r.Direction.Dot(q) * invDet
+	if v < 0 || u+v > 1 {
+		return 0, 0, 0, false
+	}
+	distance = edge2.Dot(q) * invDet
+	if distance < epsilon {
+		return 0, 0, 0, false
+	}
+	return distance, u, v, true
+}
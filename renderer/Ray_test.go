@@ -0,0 +1,127 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+const rayEpsilon = 1e-4
+
+// The threshold comparisons of mathgl are relative, too strict for the coordinates that should be 0
+func nearVec3(a, b glm.Vec3) bool {
+	return a.Sub(b).Len() <= rayEpsilon
+}
+
+func TestIntersectAABB(t *testing.T) {
+	box := AABB{Min: glm.Vec3{-1, -1, -1}, Max: glm.Vec3{1, 1, 1}}
+	tests := []struct {
+		name     string
+		ray      Ray
+		box      AABB
+		hit      bool
+		distance float32
+	}{
+		{"hit", Ray{glm.Vec3{0, 0, 5}, glm.Vec3{0, 0, -1}}, box, true, 4},
+		{"miss", Ray{glm.Vec3{3, 0, 5}, glm.Vec3{0, 0, -1}}, box, false, 0},
+		{"behind", Ray{glm.Vec3{0, 0, 5}, glm.Vec3{0, 0, 1}}, box, false, 0},
+		{"diagonal", Ray{glm.Vec3{-3, -3, 0}, glm.Vec3{1, 1, 0}.Normalize()}, box, true, 2 * math.Sqrt2},
+		{"parallel between the planes", Ray{glm.Vec3{5, 0.5, 0.5}, glm.Vec3{-1, 0, 0}}, box, true, 4},
+		{"parallel outside the planes", Ray{glm.Vec3{5, 2, 0}, glm.Vec3{-1, 0, 0}}, box, false, 0},
+		{"parallel on a plane", Ray{glm.Vec3{5, 1, 0}, glm.Vec3{-1, 0, 0}}, box, true, 4},
+		{"origin inside", Ray{glm.Vec3{0.5, 0, 0}, glm.Vec3{0, 1, 0}}, box, true, 0},
+		{"empty box", Ray{glm.Vec3{0, 0, 5}, glm.Vec3{0, 0, -1}}, EmptyAABB(), false, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distance, ok := test.ray.IntersectAABB(test.box)
+			if ok != test.hit || (ok && glm.Abs(distance-test.distance) > rayEpsilon) {
+				t.Errorf("got %v at %v, want %v at %v", ok, distance, test.hit, test.distance)
+			}
+		})
+	}
+}
+
+func TestIntersectTriangle(t *testing.T) {
+	v0, v1, v2 := glm.Vec3{0, 0, 0}, glm.Vec3{1, 0, 0}, glm.Vec3{0, 1, 0}
+	tests := []struct {
+		name     string
+		ray      Ray
+		hit      bool
+		distance float32
+		u, v     float32
+	}{
+		{"centroid", Ray{glm.Vec3{1. / 3, 1. / 3, 2}, glm.Vec3{0, 0, -1}}, true, 2, 1. / 3, 1. / 3},
+		{"first vertex", Ray{glm.Vec3{0, 0, 1}, glm.Vec3{0, 0, -1}}, true, 1, 0, 0},
+		{"on the edge of v1 and v2", Ray{glm.Vec3{0.5, 0.5, 1}, glm.Vec3{0, 0, -1}}, true, 1, 0.5, 0.5},
+		{"back face", Ray{glm.Vec3{0.25, 0.5, -3}, glm.Vec3{0, 0, 1}}, true, 3, 0.25, 0.5},
+		{"outside", Ray{glm.Vec3{1, 1, 1}, glm.Vec3{0, 0, -1}}, false, 0, 0, 0},
+		{"behind", Ray{glm.Vec3{0.25, 0.25, 1}, glm.Vec3{0, 0, 1}}, false, 0, 0, 0},
+		{"parallel", Ray{glm.Vec3{-1, 0.25, 0}, glm.Vec3{1, 0, 0}}, false, 0, 0, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distance, u, v, ok := test.ray.IntersectTriangle(v0, v1, v2)
+			if ok != test.hit {
+				t.Fatalf("got %v, want %v", ok, test.hit)
+			}
+			if !ok {
+				return
+			}
+			if glm.Abs(distance-test.distance) > rayEpsilon || glm.Abs(u-test.u) > rayEpsilon || glm.Abs(v-test.v) > rayEpsilon {
+				t.Errorf("got the distance %v and the barycentric %v, %v, want %v and %v, %v", distance, u, v, test.distance, test.u, test.v)
+			}
+			// The barycentric coordinates give back the point of the ray
+			point := v0.Mul(1 - u - v).Add(v1.Mul(u)).Add(v2.Mul(v))
+			if !nearVec3(point, test.ray.At(distance)) {
+				t.Errorf("got %v from the barycentric coordinates, want %v", point, test.ray.At(distance))
+			}
+		})
+	}
+}
+
+func TestRayTransform(t *testing.T) {
+	// The direction is normalized again after the scale
+	world := glm.Translate3D(1, 2, 3).Mul4(glm.Scale3D(2, 2, 2))
+	ray := Ray{glm.Vec3{0, 0, 0}, glm.Vec3{0, 0, 1}}.Transform(world)
+	if !nearVec3(ray.Origin, glm.Vec3{1, 2, 3}) || !nearVec3(ray.Direction, glm.Vec3{0, 0, 1}) {
+		t.Errorf("got %v, want the origin at {1, 2, 3} and the same direction", ray)
+	}
+}
+
+func TestScreenPointToRay(t *testing.T) {
+	halfFov := float64(glm.DegToRad(Zoom / 2))
+	tests := []struct {
+		name       string
+		projection ProjectionMode
+		reversedZ  bool
+		x, y       float32
+		origin     glm.Vec3
+		direction  glm.Vec3
+	}{
+		{"perspective center", Perspective, false, 100, 50, glm.Vec3{0, 0, 3 - NearPlane}, glm.Vec3{0, 0, -1}},
+		{"perspective top", Perspective, false, 100, 0,
+			glm.Vec3{0, NearPlane * float32(math.Tan(halfFov)), 3 - NearPlane},
+			glm.Vec3{0, float32(math.Sin(halfFov)), -float32(math.Cos(halfFov))}},
+		{"orthographic center", Orthographic, false, 100, 50, glm.Vec3{0, 0, 3 - NearPlane}, glm.Vec3{0, 0, -1}},
+		// The viewport is twice as wide as high, so the orthographic projection sees twice OrthoSize to each side
+		{"orthographic top left", Orthographic, false, 0, 0, glm.Vec3{-2 * OrthoSize, OrthoSize, 3 - NearPlane}, glm.Vec3{0, 0, -1}},
+		// The reversed depth has the near plane at 1, the rays don't change
+		{"reversed perspective top", Perspective, true, 100, 0,
+			glm.Vec3{0, NearPlane * float32(math.Tan(halfFov)), 3 - NearPlane},
+			glm.Vec3{0, float32(math.Sin(halfFov)), -float32(math.Cos(halfFov))}},
+		{"reversed orthographic top left", Orthographic, true, 0, 0, glm.Vec3{-2 * OrthoSize, OrthoSize, 3 - NearPlane}, glm.Vec3{0, 0, -1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			camera := NewCam(glm.Vec3{0, 0, 3}, glm.Vec3{0, 1, 0}, 0, 0)
+			camera.Projection = test.projection
+			camera.ReversedZ = test.reversedZ
+			ray := camera.ScreenPointToRay(test.x, test.y, 200, 100)
+			if !nearVec3(ray.Origin, test.origin) || !nearVec3(ray.Direction, test.direction) {
+				t.Errorf("got %v, want the origin %v and the direction %v", ray, test.origin, test.direction)
+			}
+		})
+	}
+}
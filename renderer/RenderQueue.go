@@ -0,0 +1,246 @@
+package renderer
+
+import (
+	"sort"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Texture units whose bindings the render queue remembers, the material maps
+const queueTextureUnits = 6
+
+// What the render queue needs to draw a mesh
+type DrawCommand struct {
+	Mesh *Mesh
+	// If it is nil the material of the mesh is used
+	Material  *Material
+	Shader    *Shader
+	Transform glm.Mat4
+	// Model of the mesh, for the uniforms that are the same in all its meshes like the bones. It can be nil
+	Model *Model
+	// Order of the opaque draws, if it is 0 it is calculated when the command is submitted so the draws that share state go together
+	SortKey uint64
+	// Squared distance from the camera to the center of the mesh, the order of the transparent draws
+	distance2 float32
+}
+
+// Counters of the last flush of the queue
+type RenderStats struct {
+	DrawCalls       int
+	ShaderChanges   int
+	MaterialChanges int
+	VAOChanges      int
+	TextureBinds    int
+	// Meshes that were not submitted because they are outside of the frustum
+	Culled int
+	// State changes that were not done because the state was already set
+	SkippedChanges int
+}
+
+// The render queue collects the draws of a frame and submits them in the order that changes the GL state the least.
+// The opaque draws are grouped by shader, material and vertex array, and the transparent ones go back to front after them
+type RenderQueue struct {
+	opaque      []DrawCommand
+	transparent []DrawCommand
+	Stats       RenderStats
+
+	// If it isn't nil the meshes submitted with SubmitModel that are outside of it are skipped. The skinned meshes are never culled,
+	// their bones can move them out of their box
+	Frustum *Frustum
+	culled  int
+
+	// Ids for the sort keys, they stay the same between frames
+	shaderIDs   map[*Shader]uint64
+	materialIDs map[*Material]uint64
+
+	// State left by the previous draw
+	shader   *Shader
+	material *Material
+	model    *Model
+	vao      uint32
+	textures [queueTextureUnits]uint32
+}
+
+func NewRenderQueue() *RenderQueue {
+	return &RenderQueue{
+		shaderIDs:   map[*Shader]uint64{},
+		materialIDs: map[*Material]uint64{},
+	}
+}
+
+// It adds a draw to the queue, it is drawn in the next Flush
+func (q *RenderQueue) Submit(cmd DrawCommand) {
+	if cmd.Material == nil {
+		cmd.Material = cmd.Mesh.Material
+		if cmd.Material == nil {
+			cmd.Material = defaultMaterial
+		}
+	}
+	if cmd.Material.BlendMode.Transparent() {
+		q.transparent = append(q.transparent, cmd)
+		return
+	}
+	// 2 bits for the blend mode, so the alpha tested go after the opaque ones that may hide them,
+	// 14 for the shader, 16 for the material and 32 for the vertex array
+	if cmd.SortKey == 0 {
+		cmd.SortKey = uint64(cmd.Material.BlendMode)<<62 | sortID(q.shaderIDs, cmd.Shader)<<48 | sortID(q.materialIDs, cmd.Material)<<32 | uint64(cmd.Mesh.vao)
+	}
+	q.opaque = append(q.opaque, cmd)
+}
+
+// It submits every mesh of the model with the transformation of its node, except the ones outside of the Frustum
+func (q *RenderQueue) SubmitModel(model *Model, shader *Shader) {
+	model.walkMeshes(func(mesh *Mesh, world glm.Mat4) {
+		if q.Frustum != nil && !mesh.Skinned && !q.Frustum.IntersectsAABB(mesh.Bounds.Transform(world)) {
+			q.culled++
+			return
+		}
+		q.Submit(DrawCommand{Mesh: mesh, Shader: shader, Transform: world, Model: model})
+	})
+}
+
+// It returns the id of the key for the sort keys, the first time it gives it the next one
+func sortID[K comparable](ids map[K]uint64, key K) uint64 {
+	id, ok := ids[key]
+	if !ok {
+		// The ids only have 14 bits in the key, after that they share groups but the draws are still correct
+		id = uint64(len(ids)+1) & 0x3FFF
+		ids[key] = id
+	}
+	return id
+}
+
+// It draws all the submitted commands, first the opaque and then the transparent ones, and empties the queue.
+// The uniforms of the frame, like the camera and the lights, must already be set in the shaders.
+// It returns the counters of this flush, which are also kept in Stats
+func (q *RenderQueue) Flush(cameraPosition glm.Vec3) RenderStats {
+	q.DrawOpaque()
+	return q.DrawTransparent(cameraPosition)
+}
+
+// It draws the opaque and alpha tested commands sorted by their keys and starts the counters of the frame.
+// The transparent ones stay in the queue, so the skybox can be drawn between both passes
+func (q *RenderQueue) DrawOpaque() RenderStats {
+	q.Stats = RenderStats{Culled: q.culled}
+	q.culled = 0
+	q.resetState()
+	sort.SliceStable(q.opaque, func(i, j int) bool {
+		return q.opaque[i].SortKey < q.opaque[j].SortKey
+	})
+	for i := range q.opaque {
+		q.draw(&q.opaque[i])
+	}
+	q.opaque = q.opaque[:0]
+	q.finish()
+	return q.Stats
+}
+
+// It draws the transparent commands from the furthest to the closest to the camera, so the closer ones are blended over them.
+// Its counters are added to the ones of DrawOpaque
+func (q *RenderQueue) DrawTransparent(cameraPosition glm.Vec3) RenderStats {
+	q.resetState()
+	sortBackToFront(q.transparent, cameraPosition)
+	for i := range q.transparent {
+		q.draw(&q.transparent[i])
+	}
+	q.transparent = q.transparent[:0]
+	q.finish()
+	return q.Stats
+}
+
+// Other code may have changed the state since the last pass, so we forget what we had bound
+func (q *RenderQueue) resetState() {
+	q.shader, q.material, q.model, q.vao = nil, nil, nil, 0
+	q.textures = [queueTextureUnits]uint32{}
+}
+
+// It leaves the state as the rest of the renderer expects it
+func (q *RenderQueue) finish() {
+	gl.BindVertexArray(0)
+	gl.ActiveTexture(gl.TEXTURE0)
+	ResetBlendState()
+}
+
+func (q *RenderQueue) draw(cmd *DrawCommand) {
+	shader := cmd.Shader
+	if shader != q.shader {
+		shader.Use()
+		q.shader = shader
+		// The material and model uniforms belong to the program, so they have to be set again
+		q.material, q.model = nil, nil
+		q.Stats.ShaderChanges++
+	} else {
+		q.Stats.SkippedChanges++
+	}
+
+	if cmd.Model != q.model {
+		if cmd.Model != nil {
+			cmd.Model.applyUniforms(*shader)
+		}
+		q.model = cmd.Model
+	}
+
+	// The PBR shader takes the whole material, the others bind the textures of the mesh every time
+	if _, ok := shader.Uniforms["material.baseColor"]; ok {
+		if cmd.Material != q.material {
+			cmd.Material.apply(shader, q.bindTexture)
+			q.material = cmd.Material
+			q.Stats.MaterialChanges++
+		} else {
+			q.Stats.SkippedChanges++
+		}
+	} else {
+		cmd.Mesh.bindMaterial(*shader)
+		// We don't know which units it used
+		q.textures = [queueTextureUnits]uint32{}
+		q.material = nil
+		q.Stats.MaterialChanges++
+	}
+
+	shader.SetMat4("model", cmd.Transform)
+	if _, ok := shader.Uniforms["normalMatrix"]; ok {
+		shader.SetMat3("normalMatrix", normalMatrix(cmd.Transform))
+	}
+
+	if cmd.Mesh.vao != q.vao {
+		gl.BindVertexArray(cmd.Mesh.vao)
+		q.vao = cmd.Mesh.vao
+		q.Stats.VAOChanges++
+	} else {
+		q.Stats.SkippedChanges++
+	}
+	gl.DrawElements(gl.TRIANGLES, int32(len(cmd.Mesh.Indices)), gl.UNSIGNED_INT, nil)
+	q.Stats.DrawCalls++
+}
+
+// It binds the texture to the unit only if it isn't already there
+func (q *RenderQueue) bindTexture(unit int, id uint32) {
+	if unit < queueTextureUnits && q.textures[unit] == id {
+		q.Stats.SkippedChanges++
+		return
+	}
+	gl.ActiveTexture(gl.TEXTURE0 + uint32(unit))
+	gl.BindTexture(gl.TEXTURE_2D, id)
+	if unit < queueTextureUnits {
+		q.textures[unit] = id
+	}
+	q.Stats.TextureBinds++
+}
+
+// It sorts the commands from the furthest to the closest to the position. The meshes of a node share its transform,
+// so we measure the distance to the center of the box of each mesh in the world, once per command
+func sortBackToFront(commands []DrawCommand, position glm.Vec3) {
+	for i := range commands {
+		cmd := &commands[i]
+		center := cmd.Transform.Col(3).Vec3()
+		if bounds := cmd.Mesh.Bounds.Transform(cmd.Transform); !bounds.IsEmpty() {
+			center = bounds.Center()
+		}
+		d := center.Sub(position)
+		cmd.distance2 = d.Dot(d)
+	}
+	sort.SliceStable(commands, func(i, j int) bool {
+		return commands[i].distance2 > commands[j].distance2
+	})
+}
@@ -0,0 +1,43 @@
+package renderer
+
+import (
+	"testing"
+
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+func TestSortBackToFront(t *testing.T) {
+	// Boxes of size 1 along the z axis, the camera looks from z = 10
+	box := func(z float32) AABB {
+		return AABB{Min: glm.Vec3{-0.5, -0.5, z - 0.5}, Max: glm.Vec3{0.5, 0.5, z + 0.5}}
+	}
+	near, middle, far := &Mesh{Bounds: box(5)}, &Mesh{Bounds: box(0)}, &Mesh{Bounds: box(-5)}
+	camera := glm.Vec3{0, 0, 10}
+	tests := []struct {
+		name   string
+		meshes []*Mesh
+		// One transform for all the meshes, like the meshes of the same node
+		transform glm.Mat4
+		want      []*Mesh
+	}{
+		{"same node at the origin", []*Mesh{near, far, middle}, glm.Ident4(), []*Mesh{far, middle, near}},
+		{"same node already sorted", []*Mesh{far, middle, near}, glm.Ident4(), []*Mesh{far, middle, near}},
+		{"same node moved", []*Mesh{near, far}, glm.Translate3D(0, 0, -20), []*Mesh{far, near}},
+		// Rotated half a turn around y the near mesh ends up behind the far one
+		{"same node rotated", []*Mesh{far, near}, glm.HomogRotate3DY(glm.DegToRad(180)), []*Mesh{near, far}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			commands := make([]DrawCommand, len(test.meshes))
+			for i, mesh := range test.meshes {
+				commands[i] = DrawCommand{Mesh: mesh, Transform: test.transform}
+			}
+			sortBackToFront(commands, camera)
+			for i, cmd := range commands {
+				if cmd.Mesh != test.want[i] {
+					t.Errorf("draw %d is the mesh at %v, want the one at %v", i, cmd.Mesh.Bounds.Center(), test.want[i].Bounds.Center())
+				}
+			}
+		})
+	}
+}
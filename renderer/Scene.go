@@ -0,0 +1,94 @@
+package renderer
+
+import (
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Models that are in the world together, for the queries that look at all of them
+type Scene struct {
+	Models []*Model
+}
+
+func NewScene(models ...*Model) *Scene {
+	return &Scene{Models: models}
+}
+
+func (s *Scene) Add(model *Model) {
+	s.Models = append(s.Models, model)
+}
+
+// What a ray hit
+type RaycastHit struct {
+	Model *Model
+	Mesh  *Mesh
+	// Index of the triangle in the mesh, its vertices are Indices[3*Triangle], Indices[3*Triangle+1] and Indices[3*Triangle+2]
+	Triangle int
+	Distance float32
+	Point    glm.Vec3
+	// Box of the mesh in world space, to focus the camera on it
+	Bounds AABB
+	// Weights of the three vertices of the triangle at the hit, to interpolate their attributes like the texture coordinates
+	Barycentric glm.Vec3
+}
+
+// It returns the closest triangle of the models that the ray hits. The meshes are only tested triangle by triangle
+// if the ray hits their box first. The skinned meshes are tested in their bind pose
+func (s *Scene) Raycast(ray Ray) (RaycastHit, bool) {
+	var closest RaycastHit
+	found := false
+	for _, model := range s.Models {
+		if hit, ok := model.Raycast(ray); ok && (!found || hit.Distance < closest.Distance) {
+			closest = hit
+			found = true
+		}
+	}
+	return closest, found
+}
+
+// Like Scene.Raycast but only for the meshes of the model and its instances
+func (m *Model) Raycast(ray Ray) (RaycastHit, bool) {
+	var closest RaycastHit
+	found := false
+	test := func(mesh *Mesh, world glm.Mat4) {
+		// A node scaled to 0 has no inverse and nothing visible to hit
+		if world.Det() == 0 {
+			return
+		}
+		if distance, ok := ray.IntersectAABB(mesh.Bounds.Transform(world)); !ok || (found && distance > closest.Distance) {
+			return
+		}
+		// We test the triangles in the space of the mesh, and take the hit back to the world to measure the distance
+		local := ray.Transform(world.Inv())
+		for i := 0; i+2 < len(mesh.Indices); i += 3 {
+			v0 := mesh.Vertices[mesh.Indices[i]].Position
+			v1 := mesh.Vertices[mesh.Indices[i+1]].Position
+			v2 := mesh.Vertices[mesh.Indices[i+2]].Position
+			t, u, v, ok := local.IntersectTriangle(v0, v1, v2)
+			if !ok {
+				continue
+			}
+			point := world.Mul4x1(local.At(t).Vec4(1)).Vec3()
+			distance := point.Sub(ray.Origin).Len()
+			if found && distance >= closest.Distance {
+				continue
+			}
+			closest = RaycastHit{
+				Model:       m,
+				Mesh:        mesh,
+				Triangle:    i / 3,
+				Distance:    distance,
+				Point:       point,
+				Bounds:      mesh.Bounds.Transform(world),
+				Barycentric: glm.Vec3{1 - u - v, u, v},
+			}
+			found = true
+		}
+	}
+	m.walkMeshes(func(mesh *Mesh, world glm.Mat4) {
+		test(mesh, world)
+		for _, instance := range m.Instances {
+			test(mesh, instance.Mul4(world))
+		}
+	})
+	return closest, found
+}
@@ -4,36 +4,204 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-gl/gl/v3.3-core/gl"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
+// Information about an active uniform of the program, for arrays Size is the number of elements
+type UniformInfo struct {
+	Name     string
+	Location int32
+	Type     uint32
+	Size     int32
+}
+
+// Information about an active vertex attribute of the program
+type AttributeInfo struct {
+	Name     string
+	Location int32
+	Type     uint32
+	Size     int32
+}
+
 type Shader struct {
 	ID uint32
+
+	// Reflection data of the linked program, the uniforms are also used as a location cache by the setters
+	Uniforms   map[string]UniformInfo
+	Attributes map[string]AttributeInfo
+	// Uniforms that have already been reported as missing or with a wrong type, so we only warn once
+	reported map[string]bool
+
+	// Source files of the shader, we keep them to be able to reload the program when they change
+	vertexPath   string
+	fragmentPath string
+	modTimes     [2]time.Time
 }
 
 func NewShader(vertexPath, fragmentPath string) (*Shader, error) {
+	s := &Shader{vertexPath: vertexPath, fragmentPath: fragmentPath}
+	s.modTimes = s.sourceModTimes()
+
+	program, err := buildProgram(vertexPath, fragmentPath)
+	if err != nil {
+		return nil, err
+	}
+	s.ID = program
+	s.reflect()
+
+	return s, nil
+}
+
+// Reload compiles and links the source files again, if it fails the old program is kept so we can keep drawing with it
+func (s *Shader) Reload() error {
+	s.modTimes = s.sourceModTimes()
+
+	program, err := buildProgram(s.vertexPath, s.fragmentPath)
+	if err != nil {
+		return err
+	}
+	// We swap the program only when the new one is ready, it must be called between frames
+	gl.DeleteProgram(s.ID)
+	s.ID = program
+	s.reflect()
+
+	return nil
+}
+
+// ReloadIfChanged polls the source files and reloads the shader if any of them has been modified, it returns true if the program has been swapped
+func (s *Shader) ReloadIfChanged() (bool, error) {
+	if s.sourceModTimes() == s.modTimes {
+		return false, nil
+	}
+	if err := s.Reload(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *Shader) sourceModTimes() [2]time.Time {
+	var times [2]time.Time
+	for i, path := range []string{s.vertexPath, s.fragmentPath} {
+		// If the file can't be read right now (e.g. the editor is saving it) we keep the zero time and try again later
+		if info, err := os.Stat(path); err == nil {
+			times[i] = info.ModTime()
+		}
+	}
+	return times
+}
+
+// It enumerates the active uniforms and attributes of the program and saves their locations and types
+func (s *Shader) reflect() {
+	s.Uniforms = make(map[string]UniformInfo)
+	s.Attributes = make(map[string]AttributeInfo)
+	s.reported = make(map[string]bool)
+
+	var count, maxLength int32
+	gl.GetProgramiv(s.ID, gl.ACTIVE_UNIFORMS, &count)
+	gl.GetProgramiv(s.ID, gl.ACTIVE_UNIFORM_MAX_LENGTH, &maxLength)
+	name := make([]uint8, maxLength+1)
+	for i := int32(0); i < count; i++ {
+		var length, size int32
+		var xtype uint32
+		gl.GetActiveUniform(s.ID, uint32(i), int32(len(name)), &length, &size, &xtype, &name[0])
+		uniformName := string(name[:length])
+		location := gl.GetUniformLocation(s.ID, gl.Str(uniformName+"\x00"))
+		// Uniforms inside blocks don't have a location, we can't set them with the setters
+		if location == -1 {
+			continue
+		}
+
+		// Arrays are reported as "name[0]", we save the array by its base name and every element by itself
+		baseName, isArray := strings.CutSuffix(uniformName, "[0]")
+		s.Uniforms[baseName] = UniformInfo{Name: baseName, Location: location, Type: xtype, Size: size}
+		if isArray {
+			for j := int32(0); j < size; j++ {
+				elementName := fmt.Sprintf("%s[%d]", baseName, j)
+				elementLocation := gl.GetUniformLocation(s.ID, gl.Str(elementName+"\x00"))
+				// The size of an element is what remains of the array from it, so we can upload a slice starting there
+				s.Uniforms[elementName] = UniformInfo{Name: elementName, Location: elementLocation, Type: xtype, Size: size - j}
+			}
+		}
+	}
+
+	gl.GetProgramiv(s.ID, gl.ACTIVE_ATTRIBUTES, &count)
+	gl.GetProgramiv(s.ID, gl.ACTIVE_ATTRIBUTE_MAX_LENGTH, &maxLength)
+	name = make([]uint8, maxLength+1)
+	for i := int32(0); i < count; i++ {
+		var length, size int32
+		var xtype uint32
+		gl.GetActiveAttrib(s.ID, uint32(i), int32(len(name)), &length, &size, &xtype, &name[0])
+		attributeName := string(name[:length])
+		location := gl.GetAttribLocation(s.ID, gl.Str(attributeName+"\x00"))
+		s.Attributes[attributeName] = AttributeInfo{Name: attributeName, Location: location, Type: xtype, Size: size}
+	}
+}
+
+// It returns the cached location of the uniform and checks that its GLSL type is one of the accepted ones.
+// If the uniform doesn't exist or the type doesn't match it warns once and returns -1, which OpenGL ignores
+func (s *Shader) uniformLocation(name string, accepted ...uint32) int32 {
+	uniform, ok := s.Uniforms[name]
+	if !ok {
+		s.reportOnce(name, fmt.Sprintf("Warning: Uniform '%s' not found in shader!", name))
+		return -1
+	}
+	for _, t := range accepted {
+		if uniform.Type == t {
+			return uniform.Location
+		}
+	}
+	s.reportOnce(name, fmt.Sprintf("Warning: Uniform '%s' is a %s, it can't be set with this value", name, glTypeName(uniform.Type)))
+	return -1
+}
+
+// Like uniformLocation but for arrays, it also returns how many elements can be uploaded without going past the end of the array
+func (s *Shader) arrayLocation(name string, length int, accepted ...uint32) (int32, int32) {
+	location := s.uniformLocation(name, accepted...)
+	if location == -1 || length == 0 {
+		return -1, 0
+	}
+	count := int32(length)
+	if size := s.Uniforms[name].Size; size > 0 && count > size {
+		s.reportOnce(name, fmt.Sprintf("Warning: Uniform '%s' has %d elements, %d values were given", name, size, length))
+		count = size
+	}
+	return location, count
+}
+
+func (s *Shader) reportOnce(name, msg string) {
+	if s.reported[name] {
+		return
+	}
+	s.reported[name] = true
+	fmt.Println(msg)
+}
+
+func buildProgram(vertexPath, fragmentPath string) (uint32, error) {
 	// Read the source files
 	vertexCode, err := os.ReadFile(vertexPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read vertex shader file: %w", err)
+		return 0, fmt.Errorf("failed to read vertex shader file: %w", err)
 	}
 
 	fragmentCode, err := os.ReadFile(fragmentPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read fragment shader file: %w", err)
+		return 0, fmt.Errorf("failed to read fragment shader file: %w", err)
 	}
 
 	// Compile shaders
 	vertexShader, err := compileShader(string(vertexCode), gl.VERTEX_SHADER)
 	if err != nil {
-		return nil, err
+		return 0, fmt.Errorf("%s: %w", vertexPath, err)
 	}
+	defer gl.DeleteShader(vertexShader)
 	fragmentShader, err := compileShader(string(fragmentCode), gl.FRAGMENT_SHADER)
 	if err != nil {
-		return nil, err
+		return 0, fmt.Errorf("%s: %w", fragmentPath, err)
 	}
+	defer gl.DeleteShader(fragmentShader)
 
 	// Create the program and link it
 	shaderProgram := gl.CreateProgram()
@@ -44,26 +212,25 @@ func NewShader(vertexPath, fragmentPath string) (*Shader, error) {
 	var success int32
 	gl.GetProgramiv(shaderProgram, gl.LINK_STATUS, &success)
 	if success == gl.FALSE {
+		defer gl.DeleteProgram(shaderProgram)
+
 		var logLength int32
 		gl.GetProgramiv(shaderProgram, gl.INFO_LOG_LENGTH, &logLength)
 
 		if logLength <= 0 {
-			return nil, fmt.Errorf("shader compilation failed, but no info log available")
+			return 0, fmt.Errorf("shader linking failed, but no info log available")
 		}
 		// Create a buffer filled with \0 bytes
 		infoLog := strings.Repeat("\x00", int(logLength))
 
-		gl.GetShaderInfoLog(shaderProgram, logLength, nil, gl.Str(infoLog))
+		gl.GetProgramInfoLog(shaderProgram, logLength, nil, gl.Str(infoLog))
 		// We cut at first \0
 		msg := strings.TrimRight(infoLog, "\x00")
 
-		return nil, fmt.Errorf("failed to compile the shader \n%s", msg)
+		return 0, fmt.Errorf("failed to link the shader \n%s", msg)
 	}
 
-	gl.DeleteShader(vertexShader)
-	gl.DeleteShader(fragmentShader)
-
-	return &Shader{ID: shaderProgram}, nil
+	return shaderProgram, nil
 }
 
 func compileShader(source string, shaderType uint32) (uint32, error) {
@@ -89,6 +256,7 @@ func compileShader(source string, shaderType uint32) (uint32, error) {
 		// We cut at first \0
 		msg := strings.TrimRight(infoLog, "\x00")
 
+		gl.DeleteShader(shader)
 		return 0, fmt.Errorf("failed to compile the shader \n%s", msg)
 	}
 
@@ -100,24 +268,184 @@ func (s *Shader) Use() {
 }
 
 func (s *Shader) SetBool(name string, value bool) {
-	gl.Uniform1i(gl.GetUniformLocation(s.ID, gl.Str(name+"\x00")), int32(boolToInt(value)))
+	gl.Uniform1i(s.uniformLocation(name, gl.BOOL, gl.INT), int32(boolToInt(value)))
 }
 
 func (s *Shader) SetInt(name string, value int) {
-	location := gl.GetUniformLocation(s.ID, gl.Str(name+"\x00"))
-	if location == -1 {
-		fmt.Printf("Warning: Uniform '%s' not found in shader!\n", name)
-	}
-	gl.Uniform1i(location, int32(value))
+	gl.Uniform1i(s.uniformLocation(name, intTypes...), int32(value))
+}
+
+func (s *Shader) SetUint(name string, value uint32) {
+	gl.Uniform1ui(s.uniformLocation(name, gl.UNSIGNED_INT, gl.BOOL), value)
 }
 
 func (s *Shader) SetFloat(name string, value float32) {
-	gl.Uniform1f(gl.GetUniformLocation(s.ID, gl.Str(name+"\x00")), value)
+	gl.Uniform1f(s.uniformLocation(name, gl.FLOAT), value)
 }
 
 func (s *Shader) SetMat4(name string, mat mgl32.Mat4) {
-	location := gl.GetUniformLocation(s.ID, gl.Str(name+"\x00"))
-	gl.UniformMatrix4fv(location, 1, false, &mat[0])
+	gl.UniformMatrix4fv(s.uniformLocation(name, gl.FLOAT_MAT4), 1, false, &mat[0])
+}
+
+func (s *Shader) SetVec2(name string, value mgl32.Vec2) {
+	gl.Uniform2fv(s.uniformLocation(name, gl.FLOAT_VEC2), 1, &value[0])
+}
+
+func (s *Shader) SetVec3(name string, value mgl32.Vec3) {
+	gl.Uniform3fv(s.uniformLocation(name, gl.FLOAT_VEC3), 1, &value[0])
+}
+
+func (s *Shader) SetVec4(name string, value mgl32.Vec4) {
+	gl.Uniform4fv(s.uniformLocation(name, gl.FLOAT_VEC4), 1, &value[0])
+}
+
+func (s *Shader) SetIVec2(name string, x, y int) {
+	gl.Uniform2i(s.uniformLocation(name, gl.INT_VEC2, gl.BOOL_VEC2), int32(x), int32(y))
+}
+
+func (s *Shader) SetIVec3(name string, x, y, z int) {
+	gl.Uniform3i(s.uniformLocation(name, gl.INT_VEC3, gl.BOOL_VEC3), int32(x), int32(y), int32(z))
+}
+
+func (s *Shader) SetIVec4(name string, x, y, z, w int) {
+	gl.Uniform4i(s.uniformLocation(name, gl.INT_VEC4, gl.BOOL_VEC4), int32(x), int32(y), int32(z), int32(w))
+}
+
+func (s *Shader) SetMat2(name string, mat mgl32.Mat2) {
+	gl.UniformMatrix2fv(s.uniformLocation(name, gl.FLOAT_MAT2), 1, false, &mat[0])
+}
+
+func (s *Shader) SetMat3(name string, mat mgl32.Mat3) {
+	gl.UniformMatrix3fv(s.uniformLocation(name, gl.FLOAT_MAT3), 1, false, &mat[0])
+}
+
+// The array setters upload the whole slice starting at the given uniform, it can be the array name or one of its elements
+func (s *Shader) SetFloatArray(name string, values []float32) {
+	if location, count := s.arrayLocation(name, len(values), gl.FLOAT); count > 0 {
+		gl.Uniform1fv(location, count, &values[0])
+	}
+}
+
+func (s *Shader) SetIntArray(name string, values []int32) {
+	if location, count := s.arrayLocation(name, len(values), intTypes...); count > 0 {
+		gl.Uniform1iv(location, count, &values[0])
+	}
+}
+
+func (s *Shader) SetUintArray(name string, values []uint32) {
+	if location, count := s.arrayLocation(name, len(values), gl.UNSIGNED_INT, gl.BOOL); count > 0 {
+		gl.Uniform1uiv(location, count, &values[0])
+	}
+}
+
+func (s *Shader) SetIVec2Array(name string, values [][2]int32) {
+	if location, count := s.arrayLocation(name, len(values), gl.INT_VEC2, gl.BOOL_VEC2); count > 0 {
+		gl.Uniform2iv(location, count, &values[0][0])
+	}
+}
+
+func (s *Shader) SetIVec3Array(name string, values [][3]int32) {
+	if location, count := s.arrayLocation(name, len(values), gl.INT_VEC3, gl.BOOL_VEC3); count > 0 {
+		gl.Uniform3iv(location, count, &values[0][0])
+	}
+}
+
+func (s *Shader) SetIVec4Array(name string, values [][4]int32) {
+	if location, count := s.arrayLocation(name, len(values), gl.INT_VEC4, gl.BOOL_VEC4); count > 0 {
+		gl.Uniform4iv(location, count, &values[0][0])
+	}
+}
+
+func (s *Shader) SetVec2Array(name string, values []mgl32.Vec2) {
+	if location, count := s.arrayLocation(name, len(values), gl.FLOAT_VEC2); count > 0 {
+		gl.Uniform2fv(location, count, &values[0][0])
+	}
+}
+
+func (s *Shader) SetVec3Array(name string, values []mgl32.Vec3) {
+	if location, count := s.arrayLocation(name, len(values), gl.FLOAT_VEC3); count > 0 {
+		gl.Uniform3fv(location, count, &values[0][0])
+	}
+}
+
+func (s *Shader) SetVec4Array(name string, values []mgl32.Vec4) {
+	if location, count := s.arrayLocation(name, len(values), gl.FLOAT_VEC4); count > 0 {
+		gl.Uniform4fv(location, count, &values[0][0])
+	}
+}
+
+func (s *Shader) SetMat2Array(name string, values []mgl32.Mat2) {
+	if location, count := s.arrayLocation(name, len(values), gl.FLOAT_MAT2); count > 0 {
+		gl.UniformMatrix2fv(location, count, false, &values[0][0])
+	}
+}
+
+func (s *Shader) SetMat3Array(name string, values []mgl32.Mat3) {
+	if location, count := s.arrayLocation(name, len(values), gl.FLOAT_MAT3); count > 0 {
+		gl.UniformMatrix3fv(location, count, false, &values[0][0])
+	}
+}
+
+func (s *Shader) SetMat4Array(name string, values []mgl32.Mat4) {
+	if location, count := s.arrayLocation(name, len(values), gl.FLOAT_MAT4); count > 0 {
+		gl.UniformMatrix4fv(location, count, false, &values[0][0])
+	}
+}
+
+// Set dispatches on the Go type of the value to the matching setter
+func (s *Shader) Set(name string, v any) {
+	switch value := v.(type) {
+	case bool:
+		s.SetBool(name, value)
+	case int:
+		s.SetInt(name, value)
+	case int32:
+		s.SetInt(name, int(value))
+	case uint32:
+		s.SetUint(name, value)
+	case float32:
+		s.SetFloat(name, value)
+	case float64:
+		s.SetFloat(name, float32(value))
+	case mgl32.Vec2:
+		s.SetVec2(name, value)
+	case mgl32.Vec3:
+		s.SetVec3(name, value)
+	case mgl32.Vec4:
+		s.SetVec4(name, value)
+	case mgl32.Mat2:
+		s.SetMat2(name, value)
+	case mgl32.Mat3:
+		s.SetMat3(name, value)
+	case mgl32.Mat4:
+		s.SetMat4(name, value)
+	case []float32:
+		s.SetFloatArray(name, value)
+	case []int32:
+		s.SetIntArray(name, value)
+	case []uint32:
+		s.SetUintArray(name, value)
+	case [][2]int32:
+		s.SetIVec2Array(name, value)
+	case [][3]int32:
+		s.SetIVec3Array(name, value)
+	case [][4]int32:
+		s.SetIVec4Array(name, value)
+	case []mgl32.Vec2:
+		s.SetVec2Array(name, value)
+	case []mgl32.Vec3:
+		s.SetVec3Array(name, value)
+	case []mgl32.Vec4:
+		s.SetVec4Array(name, value)
+	case []mgl32.Mat2:
+		s.SetMat2Array(name, value)
+	case []mgl32.Mat3:
+		s.SetMat3Array(name, value)
+	case []mgl32.Mat4:
+		s.SetMat4Array(name, value)
+	default:
+		s.reportOnce(name, fmt.Sprintf("Warning: Uniform '%s' can't be set with a value of type %T", name, v))
+	}
 }
 
 func (s *Shader) Delete() {
@@ -130,3 +458,50 @@ func boolToInt(value bool) int32 {
 	}
 	return 0
 }
+
+// GLSL types that can be set with an int, samplers are set with the number of their texture unit
+var intTypes = []uint32{
+	gl.INT, gl.BOOL,
+	gl.SAMPLER_1D, gl.SAMPLER_2D, gl.SAMPLER_3D, gl.SAMPLER_CUBE,
+	gl.SAMPLER_2D_SHADOW, gl.SAMPLER_CUBE_SHADOW, gl.SAMPLER_2D_ARRAY, gl.SAMPLER_2D_MULTISAMPLE,
+	gl.INT_SAMPLER_2D, gl.UNSIGNED_INT_SAMPLER_2D,
+}
+
+// Readable names of the GLSL types for the warnings
+func glTypeName(t uint32) string {
+	switch t {
+	case gl.FLOAT:
+		return "float"
+	case gl.FLOAT_VEC2:
+		return "vec2"
+	case gl.FLOAT_VEC3:
+		return "vec3"
+	case gl.FLOAT_VEC4:
+		return "vec4"
+	case gl.INT:
+		return "int"
+	case gl.UNSIGNED_INT:
+		return "uint"
+	case gl.INT_VEC2:
+		return "ivec2"
+	case gl.INT_VEC3:
+		return "ivec3"
+	case gl.INT_VEC4:
+		return "ivec4"
+	case gl.BOOL:
+		return "bool"
+	case gl.FLOAT_MAT2:
+		return "mat2"
+	case gl.FLOAT_MAT3:
+		return "mat3"
+	case gl.FLOAT_MAT4:
+		return "mat4"
+	case gl.SAMPLER_2D:
+		return "sampler2D"
+	case gl.SAMPLER_CUBE:
+		return "samplerCube"
+	case gl.SAMPLER_2D_SHADOW:
+		return "sampler2DShadow"
+	}
+	return fmt.Sprintf("GL type 0x%X", t)
+}
@@ -0,0 +1,143 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Maximum number of shadow maps that the lighting shader can sample at once, it must match MAX_SHADOW_MAPS
+const MaxShadowMaps = 4
+
+// First texture unit used by the shadow maps, the units below are left for the material textures and the environment
+const ShadowTextureUnit = 9
+
+// Default size in texels of the shadow maps
+const ShadowMapSize = 2048
+
+// Depth texture attached to a framebuffer, we render the scene from the light into it
+type ShadowMap struct {
+	FBO          uint32
+	DepthTexture uint32
+	Size         int32
+
+	// Matrix that transforms from world space to the clip space of the light, it is updated by LightSet.RenderShadows
+	LightSpace glm.Mat4
+}
+
+func NewShadowMap(size int32) (*ShadowMap, error) {
+	s := &ShadowMap{Size: size}
+
+	// We create the depth texture, it is sampled later in the lighting shader
+	gl.GenTextures(1, &s.DepthTexture)
+	gl.BindTexture(gl.TEXTURE_2D, s.DepthTexture)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.DEPTH_COMPONENT24, size, size, 0, gl.DEPTH_COMPONENT, gl.FLOAT, nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+	// Everything outside of the map is considered lit
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_BORDER)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_BORDER)
+	borderColor := []float32{1., 1., 1., 1.}
+	gl.TexParameterfv(gl.TEXTURE_2D, gl.TEXTURE_BORDER_COLOR, &borderColor[0])
+
+	// We attach it to a framebuffer without color buffer
+	gl.GenFramebuffers(1, &s.FBO)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, s.FBO)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.TEXTURE_2D, s.DepthTexture, 0)
+	gl.DrawBuffer(gl.NONE)
+	gl.ReadBuffer(gl.NONE)
+
+	status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	if status != gl.FRAMEBUFFER_COMPLETE {
+		s.Delete()
+		return nil, fmt.Errorf("shadow map framebuffer is not complete, status 0x%X", status)
+	}
+
+	return s, nil
+}
+
+// It binds the framebuffer and clears it to start the depth pass
+func (s *ShadowMap) Bind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, s.FBO)
+	gl.Viewport(0, 0, s.Size, s.Size)
+	gl.Clear(gl.DEPTH_BUFFER_BIT)
+}
+
+func (s *ShadowMap) Delete() {
+	gl.DeleteFramebuffers(1, &s.FBO)
+	gl.DeleteTextures(1, &s.DepthTexture)
+}
+
+// Up vector for a light looking in the given direction, the world up doesn't work if they are parallel
+func lightUp(direction glm.Vec3) glm.Vec3 {
+	if glm.Abs(direction.Normalize().Dot(glm.Vec3{0, 1, 0})) > 0.99 {
+		return glm.Vec3{0, 0, 1}
+	}
+	return glm.Vec3{0, 1, 0}
+}
+
+// Maximum number of point lights with shadows that the lighting shader can sample at once, it must match MAX_POINT_SHADOW_MAPS
+const MaxPointShadowMaps = 2
+
+// Default far plane and depth bias of the point light shadows
+const (
+	PointShadowFarPlane = float32(25.)
+	PointShadowBias     = float32(0.05)
+)
+
+// Depth cubemap attached to a framebuffer, we render the scene into each face from the position of a point light.
+// The faces store the distance to the light divided by the far plane
+type PointShadowMap struct {
+	FBO          uint32
+	DepthCubemap uint32
+	Size         int32
+
+	FarPlane float32
+	Bias     float32
+}
+
+func NewPointShadowMap(size int32, farPlane float32) (*PointShadowMap, error) {
+	s := &PointShadowMap{Size: size, FarPlane: farPlane, Bias: PointShadowBias}
+	s.DepthCubemap = NewDepthCubemap(size)
+
+	// We attach the first face to check the framebuffer, the face is changed in every pass
+	gl.GenFramebuffers(1, &s.FBO)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, s.FBO)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.TEXTURE_CUBE_MAP_POSITIVE_X, s.DepthCubemap, 0)
+	gl.DrawBuffer(gl.NONE)
+	gl.ReadBuffer(gl.NONE)
+
+	status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	if status != gl.FRAMEBUFFER_COMPLETE {
+		s.Delete()
+		return nil, fmt.Errorf("point shadow map framebuffer is not complete, status 0x%X", status)
+	}
+
+	return s, nil
+}
+
+// It binds the framebuffer with the given face of the cubemap attached and clears it
+func (s *PointShadowMap) BindFace(face uint32) {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, s.FBO)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.TEXTURE_CUBE_MAP_POSITIVE_X+face, s.DepthCubemap, 0)
+	gl.Viewport(0, 0, s.Size, s.Size)
+	gl.Clear(gl.DEPTH_BUFFER_BIT)
+}
+
+// It returns the view-projection matrices of the six faces of the cubemap from the given position, in the order of the faces
+func (s *PointShadowMap) FaceMatrices(position glm.Vec3) [6]glm.Mat4 {
+	projection := glm.Perspective(glm.DegToRad(90.), 1., 0.1, s.FarPlane)
+	var matrices [6]glm.Mat4
+	for i, view := range CubemapFaceViews(position) {
+		matrices[i] = projection.Mul4(view)
+	}
+	return matrices
+}
+
+func (s *PointShadowMap) Delete() {
+	gl.DeleteFramebuffers(1, &s.FBO)
+	gl.DeleteTextures(1, &s.DepthCubemap)
+}
@@ -0,0 +1,83 @@
+package renderer
+
+import (
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Maximum number of bones that can influence a vertex, and of bones of a model. They must match the skinning vertex shader
+const (
+	MaxBoneInfluences = 4
+	MaxBones          = 100
+)
+
+// Bone of a skeleton, it is animated through the node with the same name
+type Bone struct {
+	Name string
+	// Matrix that transforms from the space of the mesh to the space of the bone in the bind pose (inverse bind matrix)
+	Offset glm.Mat4
+}
+
+// Bones of a model, the vertices reference them by their index
+type Skeleton struct {
+	Bones     []Bone
+	boneIndex map[string]int
+}
+
+func NewSkeleton() *Skeleton {
+	return &Skeleton{boneIndex: make(map[string]int)}
+}
+
+// It returns the index of the bone with the given name, adding it if the skeleton doesn't have it yet
+func (s *Skeleton) AddBone(name string, offset glm.Mat4) int {
+	if index, ok := s.boneIndex[name]; ok {
+		return index
+	}
+	s.Bones = append(s.Bones, Bone{Name: name, Offset: offset})
+	s.boneIndex[name] = len(s.Bones) - 1
+	return len(s.Bones) - 1
+}
+
+// It returns the index of the bone with the given name, or -1 if there is none
+func (s *Skeleton) BoneIndex(name string) int {
+	if index, ok := s.boneIndex[name]; ok {
+		return index
+	}
+	return -1
+}
+
+// It returns the bone matrices of the bind pose, in which the skinned vertices are not moved
+func (s *Skeleton) BindPose() []glm.Mat4 {
+	matrices := make([]glm.Mat4, len(s.Bones))
+	for i := range matrices {
+		matrices[i] = glm.Ident4()
+	}
+	return matrices
+}
+
+// It adds the influence of a bone to the vertex, when all the slots are used it replaces the weakest one if the new one is stronger
+func (v *Vertex) addBoneInfluence(boneID int32, weight float32) {
+	weakest := 0
+	for i := 0; i < MaxBoneInfluences; i++ {
+		if v.Weights[i] < v.Weights[weakest] {
+			weakest = i
+		}
+	}
+	if weight > v.Weights[weakest] {
+		v.BoneIDs[weakest] = boneID
+		v.Weights[weakest] = weight
+	}
+}
+
+// It makes the weights of the vertex add up to 1, as some of them may have been discarded
+func (v *Vertex) normalizeWeights() {
+	var total float32
+	for _, w := range v.Weights {
+		total += w
+	}
+	if total == 0 {
+		return
+	}
+	for i := range v.Weights {
+		v.Weights[i] /= total
+	}
+}
@@ -0,0 +1,156 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+// Texture unit of the environment map of the skybox, after the shadow maps of the point lights
+const SkyboxTextureUnit = ShadowTextureUnit + MaxShadowMaps + MaxPointShadowMaps
+
+var ErrInvalidCubemapFaces = errors.New("cubemap faces must be square and of the same size")
+
+type Skybox struct {
+	Cubemap uint32
+	// The cubemaps that belong to someone else, like the environment, are not deleted with the skybox
+	owned bool
+}
+
+// Function that creates the skybox from six images in the order of the cubemap faces: right, left, top, bottom, front and back.
+// The cubemaps have their origin at the top left corner, so unlike the other textures the images are not flipped
+func NewSkybox(faces [6]string) (*Skybox, error) {
+	var images [6]*image.RGBA
+	for i, face := range faces {
+		rgba, err := loadRGBA(face)
+		if err != nil {
+			return nil, err
+		}
+		images[i] = rgba
+	}
+	return newSkyboxFromImages(images)
+}
+
+// Function that creates the skybox from a single image with the faces in a cross, it can be horizontal (4x3 faces) or vertical (3x4 faces)
+//
+//	    +Y                +Y
+//	-X  +Z  +X  -Z    -X  +Z  +X
+//	    -Y                -Y
+//	                      -Z
+func NewSkyboxFromCross(path string) (*Skybox, error) {
+	rgba, err := loadRGBA(path)
+	if err != nil {
+		return nil, err
+	}
+	width, height := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+
+	// Column and row of each face in the cross
+	var cells [6][2]int
+	var size int
+	vertical := false
+	switch {
+	case width*3 == height*4:
+		size = width / 4
+		cells = [6][2]int{{2, 1}, {0, 1}, {1, 0}, {1, 2}, {1, 1}, {3, 1}}
+	case width*4 == height*3:
+		size = width / 3
+		cells = [6][2]int{{2, 1}, {0, 1}, {1, 0}, {1, 2}, {1, 1}, {1, 3}}
+		vertical = true
+	default:
+		return nil, &TextureError{Path: path, Err: fmt.Errorf("%w: %dx%d is not a cross layout", ErrInvalidCubemapFaces, width, height)}
+	}
+
+	var images [6]*image.RGBA
+	for i, cell := range cells {
+		min := rgba.Bounds().Min.Add(image.Pt(cell[0]*size, cell[1]*size))
+		face := image.NewRGBA(image.Rect(0, 0, size, size))
+		draw.Draw(face, face.Bounds(), rgba, min, draw.Src)
+		images[i] = face
+	}
+	// In the vertical cross the back face is below the bottom one, so it is upside down
+	if vertical {
+		rotate180(images[5])
+	}
+	return newSkyboxFromImages(images)
+}
+
+// Function that uses a cubemap that already exists as the skybox, like the one of the environment. It is not deleted with the skybox
+func NewSkyboxFromCubemap(cubemap uint32) *Skybox {
+	return &Skybox{Cubemap: cubemap}
+}
+
+func newSkyboxFromImages(images [6]*image.RGBA) (*Skybox, error) {
+	size := images[0].Bounds().Dx()
+	for _, img := range images {
+		if img.Bounds().Dx() != size || img.Bounds().Dy() != size {
+			return nil, ErrInvalidCubemapFaces
+		}
+	}
+
+	cubemap := NewCubemap(int32(size), gl.SRGB8_ALPHA8, gl.RGBA, gl.UNSIGNED_BYTE)
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
+	for i, img := range images {
+		// The faces are colors in sRGB, like the color maps of the models
+		gl.TexImage2D(gl.TEXTURE_CUBE_MAP_POSITIVE_X+uint32(i), 0, gl.SRGB8_ALPHA8, int32(size), int32(size), 0, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
+	}
+	return &Skybox{Cubemap: cubemap, owned: true}, nil
+}
+
+// It draws the skybox behind everything, it must be drawn after the opaque objects so only the visible fragments are shaded
+func (s *Skybox) Draw(shader *Shader, camera *Camera) {
+	// The depth of the skybox is always the far one, so it has to pass when it is equal to the cleared depth
+	if camera.ReversedZ {
+		gl.DepthFunc(gl.GEQUAL)
+	} else {
+		gl.DepthFunc(gl.LEQUAL)
+	}
+	// The skybox has no size, so the orthographic cameras see it with the perspective of their field of view
+	projection := camera.ProjectionMatrix()
+	if camera.Projection == Orthographic {
+		lens := *camera
+		lens.Projection = Perspective
+		projection = lens.ProjectionMatrix()
+	}
+	shader.Use()
+	// Without the translation the skybox moves with the camera and looks infinitely far away
+	shader.SetMat4("view", camera.GetViewMatrix().Mat3().Mat4())
+	shader.SetMat4("projection", projection)
+	shader.SetBool("reversedZ", camera.ReversedZ)
+	shader.SetInt("skybox", 0)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, s.Cubemap)
+	DrawCube()
+	gl.DepthFunc(camera.DepthFunc())
+}
+
+// It binds the skybox as the environment map of the reflective and refractive materials.
+// It can be called on a nil skybox to disable them, the sampler still needs its own unit
+func (s *Skybox) Apply(shader *Shader) {
+	shader.SetInt("environmentMap", SkyboxTextureUnit)
+	shader.SetBool("hasEnvironmentMap", s != nil)
+	if s == nil {
+		return
+	}
+	gl.ActiveTexture(gl.TEXTURE0 + SkyboxTextureUnit)
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, s.Cubemap)
+	gl.ActiveTexture(gl.TEXTURE0)
+}
+
+func (s *Skybox) Delete() {
+	if s.owned {
+		gl.DeleteTextures(1, &s.Cubemap)
+	}
+}
+
+func rotate180(rgba *image.RGBA) {
+	pixels := len(rgba.Pix) / 4
+	for i := 0; i < pixels/2; i++ {
+		j := pixels - 1 - i
+		for c := 0; c < 4; c++ {
+			rgba.Pix[i*4+c], rgba.Pix[j*4+c] = rgba.Pix[j*4+c], rgba.Pix[i*4+c]
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package renderer
+
+import (
+	glm "github.com/go-gl/mathgl/mgl32"
+)
+
+// Position, rotation and scale of an object, optionally relative to a parent transform.
+// The matrices are cached and only recomputed when something has changed
+type Transform struct {
+	position glm.Vec3
+	rotation glm.Quat
+	scale    glm.Vec3
+	parent   *Transform
+
+	local glm.Mat4
+	world glm.Mat4
+	// dirty is set when the local values change, the version increases every time the world matrix is recomputed
+	// so the children can know if their parent has moved since the last time they looked
+	dirty         bool
+	version       uint64
+	parentVersion uint64
+}
+
+func NewTransform() *Transform {
+	return &Transform{
+		rotation: glm.QuatIdent(),
+		scale:    glm.Vec3{1, 1, 1},
+		dirty:    true,
+	}
+}
+
+func (t *Transform) Position() glm.Vec3 {
+	return t.position
+}
+
+func (t *Transform) SetPosition(position glm.Vec3) {
+	t.position = position
+	t.dirty = true
+}
+
+// It moves the transform by the given offset
+func (t *Transform) Translate(offset glm.Vec3) {
+	t.SetPosition(t.position.Add(offset))
+}
+
+func (t *Transform) Rotation() glm.Quat {
+	return t.rotation
+}
+
+func (t *Transform) SetRotation(rotation glm.Quat) {
+	t.rotation = rotation.Normalize()
+	t.dirty = true
+}
+
+// It rotates the transform by angle degrees around the axis, on top of its current rotation
+func (t *Transform) Rotate(angle float32, axis glm.Vec3) {
+	t.SetRotation(glm.QuatRotate(glm.DegToRad(angle), axis.Normalize()).Mul(t.rotation))
+}
+
+func (t *Transform) Scale() glm.Vec3 {
+	return t.scale
+}
+
+func (t *Transform) SetScale(scale glm.Vec3) {
+	t.scale = scale
+	t.dirty = true
+}
+
+func (t *Transform) Parent() *Transform {
+	return t.parent
+}
+
+// It makes the transform relative to the parent, nil makes it relative to the world again
+func (t *Transform) SetParent(parent *Transform) {
+	t.parent = parent
+	t.dirty = true
+}
+
+// It returns the matrix of the transform relative to its parent, translation * rotation * scale
+func (t *Transform) Local() glm.Mat4 {
+	if t.dirty {
+		translation := glm.Translate3D(t.position[0], t.position[1], t.position[2])
+		scale := glm.Scale3D(t.scale[0], t.scale[1], t.scale[2])
+		t.local = translation.Mul4(t.rotation.Mat4()).Mul4(scale)
+	}
+	return t.local
+}
+
+// It returns the matrix of the transform in world space, recomputing it only if it or any of its parents have changed
+func (t *Transform) World() glm.Mat4 {
+	changed := t.dirty || t.version == 0
+	local := t.Local()
+	t.dirty = false
+
+	if t.parent == nil {
+		if changed {
+			t.world = local
+			t.version++
+		}
+		return t.world
+	}
+
+	parentWorld := t.parent.World()
+	if changed || t.parentVersion != t.parent.version {
+		t.world = parentWorld.Mul4(local)
+		t.parentVersion = t.parent.version
+		t.version++
+	}
+	return t.world
+}
+
+// It returns the matrix to transform the normals to world space, the inverse transpose of the world matrix
+func (t *Transform) NormalMatrix() glm.Mat3 {
+	return normalMatrix(t.World())
+}
+
+// The inverse transpose keeps the normals perpendicular to the surfaces when the scale is not uniform
+func normalMatrix(m glm.Mat4) glm.Mat3 {
+	return m.Mat3().Inv().Transpose()
+}
@@ -0,0 +1,20 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec2 TexCoords;
+
+uniform sampler2D screenTexture;
+uniform float threshold;
+// Width of the soft transition around the threshold, relative to it
+uniform float knee;
+
+// It keeps only the light above the threshold, with a smooth curve instead of a hard cut so the bloom doesn't flicker
+void main() {
+    vec3 color = texture(screenTexture, TexCoords).rgb;
+    float brightness = max(color.r, max(color.g, color.b));
+    float softness = threshold * knee + 0.0001;
+    float soft = clamp(brightness - threshold + softness, 0.0, 2.0 * softness);
+    soft = soft * soft / (4.0 * softness);
+    float contribution = max(soft, brightness - threshold) / max(brightness, 0.0001);
+    FragColor = vec4(color * contribution, 1.0);
+}
@@ -0,0 +1,13 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec2 TexCoords;
+
+uniform sampler2D screenTexture;
+uniform sampler2D bloomTexture;
+uniform float intensity;
+
+void main() {
+    vec4 color = texture(screenTexture, TexCoords);
+    FragColor = vec4(color.rgb + texture(bloomTexture, TexCoords).rgb * intensity, color.a);
+}
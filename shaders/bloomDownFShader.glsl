@@ -0,0 +1,19 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec2 TexCoords;
+
+uniform sampler2D screenTexture;
+// Size of a texel of the input
+uniform vec2 texelSize;
+
+// Downsample of the dual Kawase blur, the center and the four diagonals
+void main() {
+    vec2 offset = texelSize;
+    vec3 sum = texture(screenTexture, TexCoords).rgb * 4.0;
+    sum += texture(screenTexture, TexCoords + vec2(-offset.x, -offset.y)).rgb;
+    sum += texture(screenTexture, TexCoords + vec2(offset.x, -offset.y)).rgb;
+    sum += texture(screenTexture, TexCoords + vec2(-offset.x, offset.y)).rgb;
+    sum += texture(screenTexture, TexCoords + vec2(offset.x, offset.y)).rgb;
+    FragColor = vec4(sum / 8.0, 1.0);
+}
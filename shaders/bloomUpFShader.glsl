@@ -0,0 +1,22 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec2 TexCoords;
+
+uniform sampler2D screenTexture;
+// Size of a texel of the input
+uniform vec2 texelSize;
+
+// Upsample of the dual Kawase blur, a ring of eight samples around the pixel
+void main() {
+    vec2 offset = texelSize;
+    vec3 sum = texture(screenTexture, TexCoords + vec2(-offset.x * 2.0, 0.0)).rgb;
+    sum += texture(screenTexture, TexCoords + vec2(-offset.x, offset.y)).rgb * 2.0;
+    sum += texture(screenTexture, TexCoords + vec2(0.0, offset.y * 2.0)).rgb;
+    sum += texture(screenTexture, TexCoords + vec2(offset.x, offset.y)).rgb * 2.0;
+    sum += texture(screenTexture, TexCoords + vec2(offset.x * 2.0, 0.0)).rgb;
+    sum += texture(screenTexture, TexCoords + vec2(offset.x, -offset.y)).rgb * 2.0;
+    sum += texture(screenTexture, TexCoords + vec2(0.0, -offset.y * 2.0)).rgb;
+    sum += texture(screenTexture, TexCoords + vec2(-offset.x, -offset.y)).rgb * 2.0;
+    FragColor = vec4(sum / 12.0, 1.0);
+}
@@ -0,0 +1,13 @@
+#version 330 core
+layout (location = 0) in vec3 aPos;
+
+// Vertex shader of the cube that we render from inside to fill each face of a cubemap
+out vec3 LocalPos;
+
+uniform mat4 projection;
+uniform mat4 view;
+
+void main() {
+    LocalPos = aPos;
+    gl_Position = projection * view * vec4(aPos, 1.0);
+}
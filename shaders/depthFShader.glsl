@@ -0,0 +1,22 @@
+#version 330 core
+in vec2 TexCoords;
+
+// The alpha tested materials discard the same fragments as in the lighting shaders, so their holes let the light through
+uniform bool alphaTest;
+uniform float alphaCutoff;
+uniform float baseAlpha;
+uniform bool hasBaseColorMap;
+uniform sampler2D baseColorMap;
+
+void main() {
+    // Only the depth is written, OpenGL does it for us
+    if (alphaTest) {
+        float alpha = baseAlpha;
+        if (hasBaseColorMap) {
+            alpha *= texture(baseColorMap, TexCoords).a;
+        }
+        if (alpha < alphaCutoff) {
+            discard;
+        }
+    }
+}
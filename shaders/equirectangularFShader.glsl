@@ -0,0 +1,19 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec3 LocalPos;
+
+uniform sampler2D equirectangularMap;
+
+const vec2 invAtan = vec2(0.1591, 0.3183);
+
+// It turns the direction into the longitude and latitude of the equirectangular image
+vec2 sampleSphericalMap(vec3 v) {
+    vec2 uv = vec2(atan(v.z, v.x), asin(v.y));
+    return uv * invAtan + 0.5;
+}
+
+void main() {
+    vec3 color = texture(equirectangularMap, sampleSphericalMap(normalize(LocalPos))).rgb;
+    FragColor = vec4(color, 1.0);
+}
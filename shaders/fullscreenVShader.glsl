@@ -0,0 +1,9 @@
+#version 330 core
+out vec2 TexCoords;
+
+// Triangle that covers the screen made from the index of the vertex: (-1, -1), (3, -1) and (-1, 3)
+void main() {
+    vec2 pos = vec2((gl_VertexID << 1) & 2, gl_VertexID & 2);
+    TexCoords = pos;
+    gl_Position = vec4(pos * 2.0 - 1.0, 0.0, 1.0);
+}
@@ -0,0 +1,52 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec2 TexCoords;
+
+uniform sampler2D screenTexture;
+uniform vec2 texelSize;
+
+// Limits of the edge detection and of the blur along the edges
+const float FXAA_SPAN_MAX = 8.0;
+const float FXAA_REDUCE_MUL = 1.0 / 8.0;
+const float FXAA_REDUCE_MIN = 1.0 / 128.0;
+
+float luma(vec3 color) {
+    return dot(color, vec3(0.299, 0.587, 0.114));
+}
+
+// FXAA finds the edges from the luma of the neighbours and blurs along them, it must run on the tone mapped image
+void main() {
+    vec3 rgbNW = texture(screenTexture, TexCoords + vec2(-1.0, -1.0) * texelSize).rgb;
+    vec3 rgbNE = texture(screenTexture, TexCoords + vec2(1.0, -1.0) * texelSize).rgb;
+    vec3 rgbSW = texture(screenTexture, TexCoords + vec2(-1.0, 1.0) * texelSize).rgb;
+    vec3 rgbSE = texture(screenTexture, TexCoords + vec2(1.0, 1.0) * texelSize).rgb;
+    vec4 center = texture(screenTexture, TexCoords);
+
+    float lumaNW = luma(rgbNW);
+    float lumaNE = luma(rgbNE);
+    float lumaSW = luma(rgbSW);
+    float lumaSE = luma(rgbSE);
+    float lumaM = luma(center.rgb);
+    float lumaMin = min(lumaM, min(min(lumaNW, lumaNE), min(lumaSW, lumaSE)));
+    float lumaMax = max(lumaM, max(max(lumaNW, lumaNE), max(lumaSW, lumaSE)));
+
+    // Direction of the edge, perpendicular to the gradient of the luma
+    vec2 dir = vec2(-((lumaNW + lumaNE) - (lumaSW + lumaSE)), (lumaNW + lumaSW) - (lumaNE + lumaSE));
+    float dirReduce = max((lumaNW + lumaNE + lumaSW + lumaSE) * 0.25 * FXAA_REDUCE_MUL, FXAA_REDUCE_MIN);
+    float rcpDirMin = 1.0 / (min(abs(dir.x), abs(dir.y)) + dirReduce);
+    dir = clamp(dir * rcpDirMin, vec2(-FXAA_SPAN_MAX), vec2(FXAA_SPAN_MAX)) * texelSize;
+
+    vec3 rgbA = 0.5 * (texture(screenTexture, TexCoords + dir * (1.0 / 3.0 - 0.5)).rgb +
+        texture(screenTexture, TexCoords + dir * (2.0 / 3.0 - 0.5)).rgb);
+    vec3 rgbB = rgbA * 0.5 + 0.25 * (texture(screenTexture, TexCoords + dir * -0.5).rgb +
+        texture(screenTexture, TexCoords + dir * 0.5).rgb);
+
+    // If the wider blur goes out of the range of the neighbours it crossed another edge, so we keep the narrow one
+    float lumaB = luma(rgbB);
+    if (lumaB < lumaMin || lumaB > lumaMax) {
+        FragColor = vec4(rgbA, center.a);
+    } else {
+        FragColor = vec4(rgbB, center.a);
+    }
+}
@@ -0,0 +1,12 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec2 TexCoords;
+
+uniform sampler2D screenTexture;
+uniform float gamma;
+
+void main() {
+    vec4 color = texture(screenTexture, TexCoords);
+    FragColor = vec4(pow(color.rgb, vec3(1.0 / gamma)), color.a);
+}
@@ -0,0 +1,30 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec3 LocalPos;
+
+uniform samplerCube environmentMap;
+
+const float PI = 3.14159265359;
+
+// It integrates the light of the hemisphere around the normal weighted by the cosine, the diffuse light of a surface facing that way
+void main() {
+    vec3 N = normalize(LocalPos);
+    vec3 up = abs(N.y) < 0.999 ? vec3(0.0, 1.0, 0.0) : vec3(0.0, 0.0, 1.0);
+    vec3 right = normalize(cross(up, N));
+    up = normalize(cross(N, right));
+
+    vec3 irradiance = vec3(0.0);
+    float sampleDelta = 0.025;
+    float nrSamples = 0.0;
+    for (float phi = 0.0; phi < 2.0 * PI; phi += sampleDelta) {
+        for (float theta = 0.0; theta < 0.5 * PI; theta += sampleDelta) {
+            // From spherical to tangent space and then to world space
+            vec3 tangentSample = vec3(sin(theta) * cos(phi), sin(theta) * sin(phi), cos(theta));
+            vec3 sampleVec = tangentSample.x * right + tangentSample.y * up + tangentSample.z * N;
+            irradiance += texture(environmentMap, sampleVec).rgb * cos(theta) * sin(theta);
+            nrSamples++;
+        }
+    }
+    FragColor = vec4(PI * irradiance / nrSamples, 1.0);
+}
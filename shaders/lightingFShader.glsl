@@ -0,0 +1,251 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec3 FragPos;
+in vec3 Normal;
+in vec2 TexCoords;
+in mat3 TBN;
+
+// They must match the maximums in renderer/Light.go
+#define MAX_DIR_LIGHTS 4
+#define MAX_POINT_LIGHTS 8
+#define MAX_SPOT_LIGHTS 4
+#define MAX_SHADOW_MAPS 4
+#define MAX_POINT_SHADOW_MAPS 2
+
+struct Material {
+    sampler2D texture_diffuse1;
+    sampler2D texture_specular1;
+    sampler2D texture_normal1;
+    sampler2D texture_height1;
+    bool hasNormalMap;
+    bool hasHeightMap;
+    float shininess;
+    // The fragments with less alpha than the cutoff are discarded
+    bool alphaTest;
+    float alphaCutoff;
+    // Parallax occlusion mapping with the height map, heightScale is the depth of the surface
+    bool parallaxMapping;
+    float heightScale;
+};
+
+struct DirLight {
+    vec3 direction;
+    int shadowIndex;
+
+    vec3 ambient;
+    vec3 diffuse;
+    vec3 specular;
+};
+
+struct PointLight {
+    vec3 position;
+    int shadowIndex;
+    float farPlane;
+    float shadowBias;
+
+    vec3 ambient;
+    vec3 diffuse;
+    vec3 specular;
+
+    float constant;
+    float linear;
+    float quadratic;
+};
+
+struct SpotLight {
+    vec3 position;
+    vec3 direction;
+    int shadowIndex;
+
+    vec3 ambient;
+    vec3 diffuse;
+    vec3 specular;
+
+    float constant;
+    float linear;
+    float quadratic;
+
+    float cutOff;
+    float outerCutOff;
+};
+
+uniform Material material;
+uniform vec3 viewPos;
+
+uniform int numDirLights;
+uniform int numPointLights;
+uniform int numSpotLights;
+uniform DirLight dirLights[MAX_DIR_LIGHTS];
+uniform PointLight pointLights[MAX_POINT_LIGHTS];
+uniform SpotLight spotLights[MAX_SPOT_LIGHTS];
+
+uniform bool receiveShadows;
+uniform sampler2D shadowMaps[MAX_SHADOW_MAPS];
+uniform mat4 lightSpaceMatrices[MAX_SHADOW_MAPS];
+
+uniform samplerCube pointShadowMaps[MAX_POINT_SHADOW_MAPS];
+
+// Samplers arrays can only be indexed with constants in GLSL 3.30, so we pick the shadow map by hand
+float sampleShadowMap(int index, vec2 coords) {
+    if (index == 0) return texture(shadowMaps[0], coords).r;
+    if (index == 1) return texture(shadowMaps[1], coords).r;
+    if (index == 2) return texture(shadowMaps[2], coords).r;
+    return texture(shadowMaps[3], coords).r;
+}
+
+vec2 shadowMapTexelSize(int index) {
+    if (index == 0) return 1.0 / vec2(textureSize(shadowMaps[0], 0));
+    if (index == 1) return 1.0 / vec2(textureSize(shadowMaps[1], 0));
+    if (index == 2) return 1.0 / vec2(textureSize(shadowMaps[2], 0));
+    return 1.0 / vec2(textureSize(shadowMaps[3], 0));
+}
+
+// It returns how much the fragment is in shadow from 0 to 1, averaging a 3x3 area of the shadow map (PCF)
+float calcShadow(int index, vec3 normal, vec3 lightDir) {
+    if (!receiveShadows || index < 0) {
+        return 0.0;
+    }
+    vec4 fragPosLightSpace = lightSpaceMatrices[index] * vec4(FragPos, 1.0);
+    vec3 projCoords = fragPosLightSpace.xyz / fragPosLightSpace.w * 0.5 + 0.5;
+    // Beyond the far plane of the light there is no shadow
+    if (projCoords.z > 1.0) {
+        return 0.0;
+    }
+    // The bias avoids shadow acne on the surfaces that are almost parallel to the light
+    float bias = max(0.005 * (1.0 - dot(normal, lightDir)), 0.0005);
+    vec2 texelSize = shadowMapTexelSize(index);
+    float result = 0.0;
+    for (int x = -1; x <= 1; x++) {
+        for (int y = -1; y <= 1; y++) {
+            float closestDepth = sampleShadowMap(index, projCoords.xy + vec2(x, y) * texelSize);
+            result += projCoords.z - bias > closestDepth ? 1.0 : 0.0;
+        }
+    }
+    return result / 9.0;
+}
+
+float samplePointShadowMap(int index, vec3 direction) {
+    if (index == 0) return texture(pointShadowMaps[0], direction).r;
+    return texture(pointShadowMaps[1], direction).r;
+}
+
+// Directions around the sampled one for the PCF of the point shadows
+const vec3 pointSampleOffsets[20] = vec3[](
+    vec3(1, 1, 1), vec3(1, -1, 1), vec3(-1, -1, 1), vec3(-1, 1, 1),
+    vec3(1, 1, -1), vec3(1, -1, -1), vec3(-1, -1, -1), vec3(-1, 1, -1),
+    vec3(1, 1, 0), vec3(1, -1, 0), vec3(-1, -1, 0), vec3(-1, 1, 0),
+    vec3(1, 0, 1), vec3(-1, 0, 1), vec3(1, 0, -1), vec3(-1, 0, -1),
+    vec3(0, 1, 1), vec3(0, -1, 1), vec3(0, -1, -1), vec3(0, 1, -1)
+);
+
+// Like calcShadow but for the depth cubemaps of the point lights, which store the distance to the light
+float calcPointShadow(PointLight light) {
+    if (!receiveShadows || light.shadowIndex < 0) {
+        return 0.0;
+    }
+    vec3 fragToLight = FragPos - light.position;
+    float currentDepth = length(fragToLight);
+    if (currentDepth > light.farPlane) {
+        return 0.0;
+    }
+    // The further the camera, the bigger the area we average
+    float diskRadius = (1.0 + length(viewPos - FragPos) / light.farPlane) / 25.0;
+    float result = 0.0;
+    for (int i = 0; i < 20; i++) {
+        float closestDepth = samplePointShadowMap(light.shadowIndex, fragToLight + pointSampleOffsets[i] * diskRadius) * light.farPlane;
+        result += currentDepth - light.shadowBias > closestDepth ? 1.0 : 0.0;
+    }
+    return result / 20.0;
+}
+
+// Blinn-Phong terms for a light coming from lightDir, without attenuation. The shadow only darkens the diffuse and specular terms
+vec3 blinnPhong(vec3 lightDir, vec3 ambient, vec3 diffuse, vec3 specular, vec3 normal, vec3 viewDir, vec3 albedo, vec3 specularMap, float shadow) {
+    float diff = max(dot(normal, lightDir), 0.0);
+    // We use the halfway vector instead of the reflected one
+    vec3 halfwayDir = normalize(lightDir + viewDir);
+    float spec = pow(max(dot(normal, halfwayDir), 0.0), material.shininess);
+
+    return ambient * albedo + (1.0 - shadow) * (diffuse * diff * albedo + specular * spec * specularMap);
+}
+
+float attenuation(vec3 position, float constant, float linear, float quadratic) {
+    float distance = length(position - FragPos);
+    return 1.0 / (constant + linear * distance + quadratic * (distance * distance));
+}
+
+// It marches the view ray through the layers of the height map until it goes under the surface,
+// then it interpolates between the last two layers to find where it hits
+vec2 parallaxOcclusion(vec2 texCoords, vec3 viewDirTangent) {
+    float numLayers = mix(32.0, 8.0, abs(viewDirTangent.z));
+    float layerDepth = 1.0 / numLayers;
+    vec2 deltaTexCoords = viewDirTangent.xy / viewDirTangent.z * material.heightScale / numLayers;
+    // The derivatives are undefined inside a loop that each fragment leaves at a different step,
+    // so the mipmap of every sample is chosen with the ones of the original coordinates
+    vec2 dx = dFdx(texCoords);
+    vec2 dy = dFdy(texCoords);
+
+    float currentLayerDepth = 0.0;
+    vec2 currentTexCoords = texCoords;
+    // The map stores heights, so the depth is 1 - height
+    float currentDepth = 1.0 - textureGrad(material.texture_height1, currentTexCoords, dx, dy).r;
+    for (int i = 0; i < 32 && currentLayerDepth < currentDepth; i++) {
+        currentTexCoords -= deltaTexCoords;
+        currentDepth = 1.0 - textureGrad(material.texture_height1, currentTexCoords, dx, dy).r;
+        currentLayerDepth += layerDepth;
+    }
+
+    vec2 previousTexCoords = currentTexCoords + deltaTexCoords;
+    float afterDepth = currentDepth - currentLayerDepth;
+    float beforeDepth = 1.0 - textureGrad(material.texture_height1, previousTexCoords, dx, dy).r - currentLayerDepth + layerDepth;
+    float weight = afterDepth / (afterDepth - beforeDepth);
+    return mix(currentTexCoords, previousTexCoords, weight);
+}
+
+void main() {
+    vec3 viewDir = normalize(viewPos - FragPos);
+
+    // The interpolated TBN is not exactly orthonormal anymore, so its inverse takes the view direction to tangent space
+    vec2 texCoords = TexCoords;
+    if (material.parallaxMapping && material.hasHeightMap) {
+        texCoords = parallaxOcclusion(TexCoords, normalize(inverse(TBN) * viewDir));
+    }
+
+    vec4 albedo = texture(material.texture_diffuse1, texCoords);
+    if (material.alphaTest && albedo.a < material.alphaCutoff) {
+        discard;
+    }
+    vec3 specularMap = texture(material.texture_specular1, texCoords).rgb;
+    vec3 normal = normalize(Normal);
+    // The normal map stores the normal in tangent space in the [0, 1] range
+    if (material.hasNormalMap) {
+        normal = normalize(TBN * (texture(material.texture_normal1, texCoords).rgb * 2.0 - 1.0));
+    }
+
+    vec3 result = vec3(0.0);
+    for (int i = 0; i < numDirLights; i++) {
+        DirLight light = dirLights[i];
+        vec3 lightDir = normalize(-light.direction);
+        result += blinnPhong(lightDir, light.ambient, light.diffuse, light.specular, normal, viewDir, albedo.rgb, specularMap,
+            calcShadow(light.shadowIndex, normal, lightDir));
+    }
+    for (int i = 0; i < numPointLights; i++) {
+        PointLight light = pointLights[i];
+        vec3 lightDir = normalize(light.position - FragPos);
+        result += blinnPhong(lightDir, light.ambient, light.diffuse, light.specular, normal, viewDir, albedo.rgb, specularMap,
+            calcPointShadow(light))
+            * attenuation(light.position, light.constant, light.linear, light.quadratic);
+    }
+    for (int i = 0; i < numSpotLights; i++) {
+        SpotLight light = spotLights[i];
+        vec3 lightDir = normalize(light.position - FragPos);
+        // Soft edge between the inner and the outer cone
+        float theta = dot(lightDir, normalize(-light.direction));
+        float intensity = clamp((theta - light.outerCutOff) / (light.cutOff - light.outerCutOff), 0.0, 1.0);
+        result += blinnPhong(lightDir, light.ambient, light.diffuse * intensity, light.specular * intensity, normal, viewDir, albedo.rgb, specularMap,
+            calcShadow(light.shadowIndex, normal, lightDir))
+            * attenuation(light.position, light.constant, light.linear, light.quadratic);
+    }
+
+    FragColor = vec4(result, albedo.a);
+}
@@ -0,0 +1,327 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec3 FragPos;
+in vec3 Normal;
+in vec2 TexCoords;
+in mat3 TBN;
+
+// They must match the maximums in renderer/Light.go
+#define MAX_DIR_LIGHTS 4
+#define MAX_POINT_LIGHTS 8
+#define MAX_SPOT_LIGHTS 4
+#define MAX_SHADOW_MAPS 4
+#define MAX_POINT_SHADOW_MAPS 2
+
+// Factors of the metallic/roughness model, each map multiplies its factor if the material has it
+struct Material {
+    vec4 baseColor;
+    float metallic;
+    float roughness;
+    float ao;
+    vec3 emissive;
+    float reflectivity;
+    float refraction;
+    float ior;
+    // The fragments with less alpha than the cutoff are discarded
+    bool alphaTest;
+    float alphaCutoff;
+
+    sampler2D baseColorMap;
+    sampler2D metallicMap;
+    sampler2D roughnessMap;
+    sampler2D aoMap;
+    sampler2D emissiveMap;
+    sampler2D normalMap;
+    bool hasBaseColorMap;
+    bool hasMetallicMap;
+    bool hasRoughnessMap;
+    bool hasAOMap;
+    bool hasEmissiveMap;
+    bool hasNormalMap;
+};
+
+struct DirLight {
+    vec3 direction;
+    int shadowIndex;
+
+    vec3 ambient;
+    vec3 diffuse;
+    vec3 specular;
+};
+
+struct PointLight {
+    vec3 position;
+    int shadowIndex;
+    float farPlane;
+    float shadowBias;
+
+    vec3 ambient;
+    vec3 diffuse;
+    vec3 specular;
+
+    float constant;
+    float linear;
+    float quadratic;
+};
+
+struct SpotLight {
+    vec3 position;
+    vec3 direction;
+    int shadowIndex;
+
+    vec3 ambient;
+    vec3 diffuse;
+    vec3 specular;
+
+    float constant;
+    float linear;
+    float quadratic;
+
+    float cutOff;
+    float outerCutOff;
+};
+
+uniform Material material;
+uniform vec3 viewPos;
+
+uniform int numDirLights;
+uniform int numPointLights;
+uniform int numSpotLights;
+uniform DirLight dirLights[MAX_DIR_LIGHTS];
+uniform PointLight pointLights[MAX_POINT_LIGHTS];
+uniform SpotLight spotLights[MAX_SPOT_LIGHTS];
+
+uniform bool receiveShadows;
+uniform sampler2D shadowMaps[MAX_SHADOW_MAPS];
+uniform mat4 lightSpaceMatrices[MAX_SHADOW_MAPS];
+
+uniform samplerCube pointShadowMaps[MAX_POINT_SHADOW_MAPS];
+
+// Image based lighting, the ambient light comes from the environment instead of a constant
+uniform bool useIBL;
+uniform samplerCube irradianceMap;
+uniform samplerCube prefilterMap;
+uniform sampler2D brdfLUT;
+uniform float prefilterMaxLod;
+
+// Environment map of the skybox for the reflective and refractive materials
+uniform bool hasEnvironmentMap;
+uniform samplerCube environmentMap;
+
+// Samplers arrays can only be indexed with constants in GLSL 3.30, so we pick the shadow map by hand
+float sampleShadowMap(int index, vec2 coords) {
+    if (index == 0) return texture(shadowMaps[0], coords).r;
+    if (index == 1) return texture(shadowMaps[1], coords).r;
+    if (index == 2) return texture(shadowMaps[2], coords).r;
+    return texture(shadowMaps[3], coords).r;
+}
+
+vec2 shadowMapTexelSize(int index) {
+    if (index == 0) return 1.0 / vec2(textureSize(shadowMaps[0], 0));
+    if (index == 1) return 1.0 / vec2(textureSize(shadowMaps[1], 0));
+    if (index == 2) return 1.0 / vec2(textureSize(shadowMaps[2], 0));
+    return 1.0 / vec2(textureSize(shadowMaps[3], 0));
+}
+
+// It returns how much the fragment is in shadow from 0 to 1, averaging a 3x3 area of the shadow map (PCF)
+float calcShadow(int index, vec3 normal, vec3 lightDir) {
+    if (!receiveShadows || index < 0) {
+        return 0.0;
+    }
+    vec4 fragPosLightSpace = lightSpaceMatrices[index] * vec4(FragPos, 1.0);
+    vec3 projCoords = fragPosLightSpace.xyz / fragPosLightSpace.w * 0.5 + 0.5;
+    // Beyond the far plane of the light there is no shadow
+    if (projCoords.z > 1.0) {
+        return 0.0;
+    }
+    // The bias avoids shadow acne on the surfaces that are almost parallel to the light
+    float bias = max(0.005 * (1.0 - dot(normal, lightDir)), 0.0005);
+    vec2 texelSize = shadowMapTexelSize(index);
+    float result = 0.0;
+    for (int x = -1; x <= 1; x++) {
+        for (int y = -1; y <= 1; y++) {
+            float closestDepth = sampleShadowMap(index, projCoords.xy + vec2(x, y) * texelSize);
+            result += projCoords.z - bias > closestDepth ? 1.0 : 0.0;
+        }
+    }
+    return result / 9.0;
+}
+
+float samplePointShadowMap(int index, vec3 direction) {
+    if (index == 0) return texture(pointShadowMaps[0], direction).r;
+    return texture(pointShadowMaps[1], direction).r;
+}
+
+// Directions around the sampled one for the PCF of the point shadows
+const vec3 pointSampleOffsets[20] = vec3[](
+    vec3(1, 1, 1), vec3(1, -1, 1), vec3(-1, -1, 1), vec3(-1, 1, 1),
+    vec3(1, 1, -1), vec3(1, -1, -1), vec3(-1, -1, -1), vec3(-1, 1, -1),
+    vec3(1, 1, 0), vec3(1, -1, 0), vec3(-1, -1, 0), vec3(-1, 1, 0),
+    vec3(1, 0, 1), vec3(-1, 0, 1), vec3(1, 0, -1), vec3(-1, 0, -1),
+    vec3(0, 1, 1), vec3(0, -1, 1), vec3(0, -1, -1), vec3(0, 1, -1)
+);
+
+// Like calcShadow but for the depth cubemaps of the point lights, which store the distance to the light
+float calcPointShadow(PointLight light) {
+    if (!receiveShadows || light.shadowIndex < 0) {
+        return 0.0;
+    }
+    vec3 fragToLight = FragPos - light.position;
+    float currentDepth = length(fragToLight);
+    if (currentDepth > light.farPlane) {
+        return 0.0;
+    }
+    // The further the camera, the bigger the area we average
+    float diskRadius = (1.0 + length(viewPos - FragPos) / light.farPlane) / 25.0;
+    float result = 0.0;
+    for (int i = 0; i < 20; i++) {
+        float closestDepth = samplePointShadowMap(light.shadowIndex, fragToLight + pointSampleOffsets[i] * diskRadius) * light.farPlane;
+        result += currentDepth - light.shadowBias > closestDepth ? 1.0 : 0.0;
+    }
+    return result / 20.0;
+}
+
+float attenuation(vec3 position, float constant, float linear, float quadratic) {
+    float distance = length(position - FragPos);
+    return 1.0 / (constant + linear * distance + quadratic * (distance * distance));
+}
+
+const float PI = 3.14159265359;
+
+// Trowbridge-Reitz GGX normal distribution, how many microfacets are aligned with the halfway vector
+float distributionGGX(vec3 N, vec3 H, float roughness) {
+    float a = roughness * roughness;
+    float a2 = a * a;
+    float NdotH = max(dot(N, H), 0.0);
+    float denom = NdotH * NdotH * (a2 - 1.0) + 1.0;
+    return a2 / (PI * denom * denom);
+}
+
+// Schlick-GGX geometry term, how much the microfacets shadow each other
+float geometrySchlickGGX(float NdotV, float roughness) {
+    float r = roughness + 1.0;
+    float k = (r * r) / 8.0;
+    return NdotV / (NdotV * (1.0 - k) + k);
+}
+
+float geometrySmith(vec3 N, vec3 V, vec3 L, float roughness) {
+    return geometrySchlickGGX(max(dot(N, V), 0.0), roughness) * geometrySchlickGGX(max(dot(N, L), 0.0), roughness);
+}
+
+// Fresnel-Schlick, how much light is reflected instead of refracted
+vec3 fresnelSchlick(float cosTheta, vec3 F0) {
+    return F0 + (1.0 - F0) * pow(clamp(1.0 - cosTheta, 0.0, 1.0), 5.0);
+}
+
+// Fresnel for the ambient light, the rough surfaces reflect less at grazing angles
+vec3 fresnelSchlickRoughness(float cosTheta, vec3 F0, float roughness) {
+    return F0 + (max(vec3(1.0 - roughness), F0) - F0) * pow(clamp(1.0 - cosTheta, 0.0, 1.0), 5.0);
+}
+
+// Cook-Torrance BRDF for the light coming from L with the given radiance, multiplied by the cosine of the angle of incidence
+vec3 cookTorrance(vec3 L, vec3 radiance, vec3 N, vec3 V, vec3 albedo, float metallic, float roughness) {
+    vec3 H = normalize(V + L);
+    // Dielectrics reflect around 4% of the light, metals tint the reflection with their color
+    vec3 F0 = mix(vec3(0.04), albedo, metallic);
+
+    float NDF = distributionGGX(N, H, roughness);
+    float G = geometrySmith(N, V, L, roughness);
+    vec3 F = fresnelSchlick(max(dot(H, V), 0.0), F0);
+
+    vec3 specular = (NDF * G * F) / (4.0 * max(dot(N, V), 0.0) * max(dot(N, L), 0.0) + 0.0001);
+    // The light that is not reflected is refracted, and metals absorb all of it
+    vec3 kD = (vec3(1.0) - F) * (1.0 - metallic);
+
+    return (kD * albedo / PI + specular) * radiance * max(dot(N, L), 0.0);
+}
+
+void main() {
+    vec4 baseColor = material.baseColor;
+    if (material.hasBaseColorMap) {
+        baseColor *= texture(material.baseColorMap, TexCoords);
+    }
+    if (material.alphaTest && baseColor.a < material.alphaCutoff) {
+        discard;
+    }
+    vec3 albedo = baseColor.rgb;
+    float metallic = material.metallic;
+    if (material.hasMetallicMap) {
+        metallic *= texture(material.metallicMap, TexCoords).b;
+    }
+    float roughness = material.roughness;
+    if (material.hasRoughnessMap) {
+        roughness *= texture(material.roughnessMap, TexCoords).g;
+    }
+    float ao = material.ao;
+    if (material.hasAOMap) {
+        ao *= texture(material.aoMap, TexCoords).r;
+    }
+    vec3 emissive = material.emissive;
+    if (material.hasEmissiveMap) {
+        emissive *= texture(material.emissiveMap, TexCoords).rgb;
+    }
+
+    vec3 N = normalize(Normal);
+    // The normal map stores the normal in tangent space in the [0, 1] range
+    if (material.hasNormalMap) {
+        N = normalize(TBN * (texture(material.normalMap, TexCoords).rgb * 2.0 - 1.0));
+    }
+    vec3 V = normalize(viewPos - FragPos);
+
+    vec3 Lo = vec3(0.0);
+    for (int i = 0; i < numDirLights; i++) {
+        DirLight light = dirLights[i];
+        vec3 L = normalize(-light.direction);
+        Lo += cookTorrance(L, light.diffuse, N, V, albedo, metallic, roughness) * (1.0 - calcShadow(light.shadowIndex, N, L));
+    }
+    for (int i = 0; i < numPointLights; i++) {
+        PointLight light = pointLights[i];
+        vec3 L = normalize(light.position - FragPos);
+        vec3 radiance = light.diffuse * attenuation(light.position, light.constant, light.linear, light.quadratic);
+        Lo += cookTorrance(L, radiance, N, V, albedo, metallic, roughness) * (1.0 - calcPointShadow(light));
+    }
+    for (int i = 0; i < numSpotLights; i++) {
+        SpotLight light = spotLights[i];
+        vec3 L = normalize(light.position - FragPos);
+        // Soft edge between the inner and the outer cone
+        float theta = dot(L, normalize(-light.direction));
+        float intensity = clamp((theta - light.outerCutOff) / (light.cutOff - light.outerCutOff), 0.0, 1.0);
+        vec3 radiance = light.diffuse * intensity * attenuation(light.position, light.constant, light.linear, light.quadratic);
+        Lo += cookTorrance(L, radiance, N, V, albedo, metallic, roughness) * (1.0 - calcShadow(light.shadowIndex, N, L));
+    }
+
+    // Without an environment the ambient light is a constant
+    vec3 ambient = vec3(0.03) * albedo * ao;
+    if (useIBL) {
+        vec3 F0 = mix(vec3(0.04), albedo, metallic);
+        float NdotV = max(dot(N, V), 0.0);
+        vec3 F = fresnelSchlickRoughness(NdotV, F0, roughness);
+        vec3 kD = (vec3(1.0) - F) * (1.0 - metallic);
+        vec3 diffuse = texture(irradianceMap, N).rgb * albedo;
+
+        // The prefiltered map has the reflection blurred for the roughness in its mips, the LUT gives the scale and bias of F0
+        vec3 R = reflect(-V, N);
+        vec3 prefiltered = textureLod(prefilterMap, R, roughness * prefilterMaxLod).rgb;
+        vec2 brdf = texture(brdfLUT, vec2(NdotV, roughness)).rg;
+        vec3 specular = prefiltered * (F * brdf.x + brdf.y);
+
+        ambient = (kD * diffuse + specular) * ao;
+    }
+
+    vec3 color = ambient + Lo;
+    // The environment map replaces part of the shading with what is reflected and what is seen through the surface
+    if (hasEnvironmentMap) {
+        vec3 I = -V;
+        if (material.refraction > 0.0) {
+            vec3 refracted = texture(environmentMap, refract(I, N, 1.0 / material.ior)).rgb;
+            color = mix(color, refracted * albedo, material.refraction);
+        }
+        if (material.reflectivity > 0.0) {
+            vec3 reflected = texture(environmentMap, reflect(I, N)).rgb;
+            color = mix(color, reflected, material.reflectivity);
+        }
+    }
+
+    FragColor = vec4(color + emissive, baseColor.a);
+}
@@ -0,0 +1,27 @@
+#version 330 core
+in vec3 FragPos;
+in vec2 TexCoords;
+
+uniform vec3 lightPos;
+uniform float farPlane;
+
+// The alpha tested materials discard the same fragments as in the lighting shaders, so their holes let the light through
+uniform bool alphaTest;
+uniform float alphaCutoff;
+uniform float baseAlpha;
+uniform bool hasBaseColorMap;
+uniform sampler2D baseColorMap;
+
+void main() {
+    if (alphaTest) {
+        float alpha = baseAlpha;
+        if (hasBaseColorMap) {
+            alpha *= texture(baseColorMap, TexCoords).a;
+        }
+        if (alpha < alphaCutoff) {
+            discard;
+        }
+    }
+    // We store the linear distance to the light mapped to [0, 1], so every face of the cubemap uses the same scale
+    gl_FragDepth = length(FragPos - lightPos) / farPlane;
+}
@@ -0,0 +1,45 @@
+#version 330 core
+layout (location = 0) in vec3 aPos;
+layout (location = 2) in vec2 aTexCoord;
+layout (location = 3) in ivec4 aBoneIDs;
+layout (location = 4) in vec4 aWeights;
+// Model matrix of each copy in the instanced draws, from location 7 to 10
+layout (location = 7) in mat4 aInstanceModel;
+
+out vec3 FragPos;
+out vec2 TexCoords;
+
+// They must match the maximums in renderer/Skeleton.go
+#define MAX_BONES 100
+#define MAX_BONE_INFLUENCES 4
+
+uniform mat4 model;
+uniform mat4 lightSpaceMatrix;
+uniform bool skinned;
+uniform bool instanced;
+uniform mat4 boneMatrices[MAX_BONES];
+
+void main(){
+  // The instances are placed by their own matrix on top of the model one
+  mat4 world = instanced ? aInstanceModel * model : model;
+
+  TexCoords = aTexCoord;
+
+  // The skinned meshes cast the shadow of their current pose, like in the skinning vertex shader
+  mat4 skin = mat4(1.0);
+  if (skinned) {
+    mat4 weighted = mat4(0.0);
+    float totalWeight = 0.0;
+    for (int i = 0; i < MAX_BONE_INFLUENCES; i++) {
+      if (aWeights[i] > 0.0) {
+        weighted += boneMatrices[aBoneIDs[i]] * aWeights[i];
+        totalWeight += aWeights[i];
+      }
+    }
+    if (totalWeight > 0.0) {
+      skin = weighted;
+    }
+  }
+  FragPos = vec3(world * skin * vec4(aPos, 1.0));
+  gl_Position = lightSpaceMatrix * vec4(FragPos, 1.0);
+}
@@ -0,0 +1,74 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec3 LocalPos;
+
+uniform samplerCube environmentMap;
+uniform float roughness;
+// Size of a face of the environment map
+uniform float resolution;
+
+const float PI = 3.14159265359;
+const uint SAMPLE_COUNT = 1024u;
+
+float distributionGGX(float NdotH, float roughness) {
+    float a = roughness * roughness;
+    float a2 = a * a;
+    float denom = NdotH * NdotH * (a2 - 1.0) + 1.0;
+    return a2 / (PI * denom * denom);
+}
+
+// Low discrepancy sequence, the samples are spread more evenly than random ones
+float radicalInverseVdC(uint bits) {
+    bits = (bits << 16u) | (bits >> 16u);
+    bits = ((bits & 0x55555555u) << 1u) | ((bits & 0xAAAAAAAAu) >> 1u);
+    bits = ((bits & 0x33333333u) << 2u) | ((bits & 0xCCCCCCCCu) >> 2u);
+    bits = ((bits & 0x0F0F0F0Fu) << 4u) | ((bits & 0xF0F0F0F0u) >> 4u);
+    bits = ((bits & 0x00FF00FFu) << 8u) | ((bits & 0xFF00FF00u) >> 8u);
+    return float(bits) * 2.3283064365386963e-10;
+}
+
+vec2 hammersley(uint i, uint n) {
+    return vec2(float(i) / float(n), radicalInverseVdC(i));
+}
+
+// It picks a halfway vector around the normal, more of them close to it the smoother the surface
+vec3 importanceSampleGGX(vec2 Xi, vec3 N, float roughness) {
+    float a = roughness * roughness;
+    float phi = 2.0 * PI * Xi.x;
+    float cosTheta = sqrt((1.0 - Xi.y) / (1.0 + (a * a - 1.0) * Xi.y));
+    float sinTheta = sqrt(1.0 - cosTheta * cosTheta);
+    vec3 H = vec3(cos(phi) * sinTheta, sin(phi) * sinTheta, cosTheta);
+
+    vec3 up = abs(N.z) < 0.999 ? vec3(0.0, 0.0, 1.0) : vec3(1.0, 0.0, 0.0);
+    vec3 tangent = normalize(cross(up, N));
+    vec3 bitangent = cross(N, tangent);
+    return normalize(tangent * H.x + bitangent * H.y + N * H.z);
+}
+
+// We assume that the view direction is the normal, so the reflections lose their stretching at grazing angles
+void main() {
+    vec3 N = normalize(LocalPos);
+    vec3 V = N;
+
+    vec3 prefiltered = vec3(0.0);
+    float totalWeight = 0.0;
+    for (uint i = 0u; i < SAMPLE_COUNT; i++) {
+        vec3 H = importanceSampleGGX(hammersley(i, SAMPLE_COUNT), N, roughness);
+        vec3 L = normalize(2.0 * dot(V, H) * H - V);
+        float NdotL = max(dot(N, L), 0.0);
+        if (NdotL > 0.0) {
+            // The samples with less probability read from a lower mip, this avoids bright dots
+            float NdotH = max(dot(N, H), 0.0);
+            float HdotV = max(dot(H, V), 0.0);
+            float pdf = distributionGGX(NdotH, roughness) * NdotH / (4.0 * HdotV) + 0.0001;
+            float saTexel = 4.0 * PI / (6.0 * resolution * resolution);
+            float saSample = 1.0 / (float(SAMPLE_COUNT) * pdf + 0.0001);
+            float mipLevel = roughness == 0.0 ? 0.0 : 0.5 * log2(saSample / saTexel);
+
+            prefiltered += textureLod(environmentMap, L, mipLevel).rgb * NdotL;
+            totalWeight += NdotL;
+        }
+    }
+    FragColor = vec4(prefiltered / totalWeight, 1.0);
+}
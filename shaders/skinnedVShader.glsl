@@ -0,0 +1,63 @@
+#version 330 core
+layout (location = 0) in vec3 aPos;
+layout (location = 1) in vec3 aNormal;
+layout (location = 2) in vec2 aTexCoord;
+layout (location = 3) in ivec4 aBoneIDs;
+layout (location = 4) in vec4 aWeights;
+layout (location = 5) in vec3 aTangent;
+layout (location = 6) in vec3 aBitangent;
+// Model matrix of each copy in the instanced draws, from location 7 to 10
+layout (location = 7) in mat4 aInstanceModel;
+
+out vec3 FragPos;
+out vec3 Normal;
+out vec2 TexCoords;
+out mat3 TBN;
+
+// They must match the maximums in renderer/Skeleton.go
+#define MAX_BONES 100
+#define MAX_BONE_INFLUENCES 4
+
+uniform mat4 model;
+uniform mat3 normalMatrix;
+uniform mat4 view;
+uniform mat4 projection;
+uniform bool instanced;
+uniform mat4 boneMatrices[MAX_BONES];
+
+void main(){
+  // The instances are placed by their own matrix on top of the model one, so their normal matrix is calculated here
+  mat4 world = model;
+  mat3 worldNormal = normalMatrix;
+  if (instanced) {
+    world = aInstanceModel * model;
+    worldNormal = transpose(inverse(mat3(world)));
+  }
+
+  // We move the vertex with the weighted sum of its bones, the vertices without bones stay as they are
+  mat4 skin = mat4(0.0);
+  float totalWeight = 0.0;
+  for (int i = 0; i < MAX_BONE_INFLUENCES; i++) {
+    if (aWeights[i] > 0.0) {
+      skin += boneMatrices[aBoneIDs[i]] * aWeights[i];
+      totalWeight += aWeights[i];
+    }
+  }
+  if (totalWeight == 0.0) {
+    skin = mat4(1.0);
+  }
+
+  // Then we pass the position and the normal in world space to do the lighting there, like the lighting vertex shader
+  FragPos = vec3(world * skin * vec4(aPos, 1.0));
+  Normal = worldNormal * mat3(skin) * aNormal;
+  // Tangent space in world space, for the normal maps. The tangent lies on the surface, so it is moved with the model and the
+  // skin matrices like the positions, and it is made perpendicular to the normal because the tangents of Assimp aren't orthonormal.
+  // The bitangent is rebuilt from them keeping the handedness of the UVs
+  vec3 N = normalize(Normal);
+  vec3 T = mat3(world) * mat3(skin) * aTangent;
+  T = normalize(T - dot(T, N) * N);
+  vec3 B = cross(N, T) * (dot(cross(N, T), mat3(world) * mat3(skin) * aBitangent) < 0.0 ? -1.0 : 1.0);
+  TBN = mat3(T, B, N);
+  TexCoords = aTexCoord;
+  gl_Position = projection * view * vec4(FragPos, 1.0);
+}
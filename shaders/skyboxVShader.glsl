@@ -0,0 +1,17 @@
+#version 330 core
+layout (location = 0) in vec3 aPos;
+
+out vec3 TexCoords;
+
+uniform mat4 projection;
+uniform mat4 view;
+uniform bool reversedZ;
+
+void main() {
+    // The direction from the center of the cube is the coordinate in the cubemap
+    TexCoords = aPos;
+    vec4 pos = projection * view * vec4(aPos, 1.0);
+    // The z equal to w gives a depth of 1 after the perspective division, the furthest possible
+    // The reversed depth has the far plane at 0 instead, the clip control takes z from 0 to w as the depth
+    gl_Position = reversedZ ? vec4(pos.xy, 0.0, pos.w) : pos.xyww;
+}
@@ -0,0 +1,33 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec2 TexCoords;
+
+uniform sampler2D screenTexture;
+uniform float exposure;
+// 0 is ACES, 1 is Reinhard and 2 only applies the exposure. They must match renderer/PostProcess.go
+uniform int toneMapOperator;
+
+// Fit of the ACES filmic curve by Krzysztof Narkowicz
+vec3 aces(vec3 x) {
+    const float a = 2.51;
+    const float b = 0.03;
+    const float c = 2.43;
+    const float d = 0.59;
+    const float e = 0.14;
+    return clamp((x * (a * x + b)) / (x * (c * x + d) + e), 0.0, 1.0);
+}
+
+void main() {
+    vec4 color = texture(screenTexture, TexCoords);
+    vec3 hdr = color.rgb * exposure;
+    vec3 mapped;
+    if (toneMapOperator == 0) {
+        mapped = aces(hdr);
+    } else if (toneMapOperator == 1) {
+        mapped = hdr / (hdr + vec3(1.0));
+    } else {
+        mapped = clamp(hdr, 0.0, 1.0);
+    }
+    FragColor = vec4(mapped, color.a);
+}
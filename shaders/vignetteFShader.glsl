@@ -0,0 +1,17 @@
+#version 330 core
+out vec4 FragColor;
+
+in vec2 TexCoords;
+
+uniform sampler2D screenTexture;
+uniform float intensity;
+// Distance from the center where the darkening starts, and how long it takes to reach its maximum
+uniform float radius;
+uniform float softness;
+
+void main() {
+    vec4 color = texture(screenTexture, TexCoords);
+    float dist = length(TexCoords - vec2(0.5));
+    float vignette = smoothstep(radius, radius - softness, dist);
+    FragColor = vec4(color.rgb * mix(1.0, vignette, intensity), color.a);
+}
@@ -0,0 +1,5 @@
+module github.com/bloeys/assimp-go
+
+go 1.17
+
+require github.com/bloeys/gglm v0.3.1